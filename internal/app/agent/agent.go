@@ -10,25 +10,48 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"registry-cleaner-agent/internal/pkg/disk_monitor"
 	"registry-cleaner-agent/internal/pkg/fs_analyzer"
 	"registry-cleaner-agent/internal/pkg/garbage_collector"
+	"registry-cleaner-agent/internal/pkg/metrics"
+	"registry-cleaner-agent/internal/pkg/oci_layout"
+	"registry-cleaner-agent/internal/pkg/promote"
+	"registry-cleaner-agent/internal/pkg/quota"
 	"registry-cleaner-agent/internal/pkg/registry_api"
+	"registry-cleaner-agent/internal/pkg/retention"
+	"registry-cleaner-agent/internal/pkg/search"
 	"registry-cleaner-agent/internal/pkg/status"
+	"registry-cleaner-agent/internal/pkg/storage_index"
+	"registry-cleaner-agent/internal/pkg/tags"
+	"registry-cleaner-agent/internal/pkg/untagged"
 	"sync"
 	"syscall"
 	"time"
 )
 
 type Agent struct {
-	config *Config
-	router *mux.Router
-	server *http.Server
-	gc     *garbage_collector.GCHandler
-	wg     *sync.WaitGroup
+	config    *Config
+	router    *mux.Router
+	server    *http.Server
+	api       *registry_api.RegistryApiHandler
+	gc        *garbage_collector.GCHandler
+	retention *retention.Handler
+	quota     *quota.Handler
+	disk      *disk_monitor.Monitor
+	untagged  *untagged.Handler
+	index     *storage_index.Handler
+	tags      *tags.Handler
+	search    *search.Handler
+	promote   *promote.Handler
+	layout    *oci_layout.Handler
+	metrics   *metrics.Metrics
+	wg        *sync.WaitGroup
 }
 
 const (
 	ShutdownTimeout = 5 * time.Second
+	// DefaultDiskCheckInterval is used when disk_check_interval is not configured
+	DefaultDiskCheckInterval = time.Minute
 )
 
 func New(config *Config) *Agent {
@@ -47,6 +70,8 @@ func (a *Agent) Run() {
 	}
 
 	a.configureServer()
+	a.disk.Start()
+	a.registerOnShutdown(a.disk.Stop)
 	go func() {
 		if err = a.server.ListenAndServe(); err != http.ErrServerClosed {
 			log.Fatalf("HTTP server ListenAndServe: %v", err)
@@ -116,39 +141,169 @@ func (a *Agent) shutdown() {
 
 }
 
-func (a *Agent) initHandlers() (*registry_api.RegistryApiHandler, *garbage_collector.GCHandler, error) {
+func (a *Agent) initHandlers() error {
 	stm, err := status.InitStatusManager(a.config.BitCaskStoragePath)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+	a.api, err = registry_api.InitApiHandler(a.config.ApiUrl, stm)
+	if err != nil {
+		return err
 	}
-	rah, err := registry_api.InitApiHandler(a.config.ApiUrl, stm)
+	a.api.PersistSummaries = a.config.SummaryCachePersistent
+	a.metrics, err = metrics.InitMetrics(stm, a.api.Ping)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	gc := garbage_collector.NewGarbageCollector(
 		a.config.ContainerName, a.config.ReadonlyContainerName, a.config.RegistryConfig)
 	fsa := fs_analyzer.NewFSAnalyzer(a.config.RegistryMountPoint)
-	gch, err := garbage_collector.InitGCHandler(gc, stm, fsa)
+	a.gc, err = garbage_collector.InitGCHandler(gc, stm, fsa)
+	if err != nil {
+		return err
+	}
+	a.gc.Metrics = a.metrics
+	a.gc.HistoryRetention = garbage_collector.HistoryRetention{
+		MaxAge:     time.Duration(a.config.GCHistoryRetentionDays) * 24 * time.Hour,
+		MaxEntries: a.config.GCHistoryMaxEntries,
+	}
+	err = a.gc.EnableCron(a.config.GCIndexSchedule, a.config.GCRemovalSchedule)
+	if err != nil {
+		return err
+	}
+	policy := retention.Policy{
+		MaxIdle: time.Duration(a.config.RetentionUnpulledDays) * 24 * time.Hour,
+	}
+	a.retention, err = retention.InitRetentionHandler(a.api.Client, stm, policy)
+	if err != nil {
+		return err
+	}
+	err = a.retention.EnableCron(a.config.RetentionSchedule)
+	if err != nil {
+		return err
+	}
+	a.quota, err = quota.InitQuotaHandler(fsa, a.config.Quotas)
+	if err != nil {
+		return err
+	}
+	interval := DefaultDiskCheckInterval
+	if a.config.DiskCheckInterval != "" {
+		interval, err = time.ParseDuration(a.config.DiskCheckInterval)
+		if err != nil {
+			return err
+		}
+	}
+	watermarks := disk_monitor.Watermarks{
+		High: a.config.DiskHighWatermark,
+		Low:  a.config.DiskLowWatermark,
+	}
+	a.disk, err = disk_monitor.InitDiskMonitor(
+		a.config.RegistryMountPoint, watermarks, interval, a.retention, a.gc, stm)
+	if err != nil {
+		return err
+	}
+	if a.config.DiskRoundInterval != "" {
+		a.disk.RoundInterval, err = time.ParseDuration(a.config.DiskRoundInterval)
+		if err != nil {
+			return err
+		}
+	}
+	if a.config.DiskMinFreed > 0 {
+		a.disk.MinFreed = a.config.DiskMinFreed
+	}
+	a.untagged, err = untagged.InitUntaggedHandler(fsa, a.api.Client)
+	if err != nil {
+		return err
+	}
+	if a.config.UntaggedMinAge != "" {
+		a.untagged.MinAge, err = time.ParseDuration(a.config.UntaggedMinAge)
+		if err != nil {
+			return err
+		}
+	}
+	a.gc.Untagged = a.untagged
+	a.index, err = storage_index.InitIndexHandler(fsa)
+	if err != nil {
+		return err
+	}
+	a.api.Referrers = a.index
+	a.tags, err = tags.InitTagsHandler(fsa, a.api.Client, stm)
+	if err != nil {
+		return err
+	}
+	a.promote, err = promote.InitPromoteHandler(a.api.Client, a.quota, stm)
+	if err != nil {
+		return err
+	}
+	a.promote.AuditRetention = promote.AuditRetention{
+		MaxAge:     time.Duration(a.config.AuditRetentionDays) * 24 * time.Hour,
+		MaxEntries: a.config.AuditMaxEntries,
+	}
+	a.layout, err = oci_layout.InitLayoutHandler(a.api.Client, a.quota)
+	if err != nil {
+		return err
+	}
+	if a.config.ImportMaxSize != "" {
+		a.layout.MaxImportSize, err = quota.ParseSize(a.config.ImportMaxSize)
+		if err != nil {
+			return err
+		}
+	}
+	a.search, err = search.InitSearchHandler(fsa, stm)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	err = gch.EnableCron(a.config.GCIndexSchedule, a.config.GCRemovalSchedule)
-	return rah, gch, err
+	return a.search.EnableCron(a.config.GCIndexSchedule)
 }
 
 func (a *Agent) configureRouter() error {
-	registryApiHandler, gch, err := a.initHandlers()
+	err := a.initHandlers()
 	if err != nil {
 		return err
 	}
+	a.registerRoutes()
+	return nil
+}
+
+// registerRoutes binds initialized handlers; agent routes come first, everything else is proxied to the registry
+func (a *Agent) registerRoutes() {
 	a.router.Use(func(next http.Handler) http.Handler { return handlers.CombinedLoggingHandler(os.Stdout, next) })
-	a.router.HandleFunc("/v2/status", registryApiHandler.StatusHandler)
-	a.router.HandleFunc("/v2/{repo}/manifests/{tag}/summary", registryApiHandler.ManifestSummaryHandler).Methods("GET")
-	a.router.HandleFunc("/v2/{repo}/manifests/{tag}/summary", registryApiHandler.ManifestSummaryHeadHandler).Methods("HEAD")
+	a.router.HandleFunc("/v2/status", a.api.StatusHandler)
+	a.router.Handle("/metrics", a.metrics.Handler()).Methods("GET")
+	a.router.HandleFunc("/v2/_catalog/summary", a.index.CatalogSummaryHandler).Methods("GET")
+	a.router.HandleFunc("/v2/_blobs/"+digestPattern+"/references", a.index.BlobReferencesHandler).Methods("GET")
+	a.router.HandleFunc("/v2/_search", a.search.SearchHandler).Methods("GET")
+	a.router.HandleFunc("/v2/_sharing/blobs", a.index.BlobSharingHandler).Methods("GET")
+	a.router.HandleFunc("/v2/_sharing/images", a.index.ImageSharingHandler).Methods("GET")
+	a.router.HandleFunc("/v2/_sharing/top", a.index.TopLayersHandler).Methods("GET")
+	a.router.HandleFunc(repoRoute("manifests", referencePattern, "summary"), validateName(a.api.ManifestSummaryHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("manifests", referencePattern, "summary"), validateName(a.api.ManifestSummaryHeadHandler)).Methods("HEAD")
+	a.router.HandleFunc(repoRoute("manifests", referencePattern, "config"), validateName(a.api.ManifestConfigHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("manifests", referencePattern, "export"), validateName(a.layout.ExportHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("import"), validateName(a.layout.ImportHandler)).Methods("POST")
+	a.router.HandleFunc(repoRoute("diff"), validateName(a.api.DiffHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("manifests", digestPattern, "tags"), validateName(a.search.DigestTagsHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("tags", "summary"), validateName(a.api.TagsSummaryHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("tags", tagPattern, "stats"), validateName(a.api.TagStatsHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("tags", tagPattern), validateName(a.tags.TagDeleteHandler)).Methods("DELETE")
 
-	a.router.HandleFunc("/v2/garbage", gch.GarbageGetHandler).Methods("GET")
-	a.router.HandleFunc("/v2/garbage", gch.GarbageDeleteHandler).Methods("DELETE")
+	a.router.HandleFunc("/v2/garbage", a.gc.GarbageGetHandler).Methods("GET")
+	a.router.HandleFunc("/v2/garbage", a.gc.GarbageDeleteHandler).Methods("DELETE")
+	a.router.HandleFunc("/v2/garbage/history", a.gc.GarbageHistoryHandler).Methods("GET")
 
-	a.router.PathPrefix("/").HandlerFunc(registryApiHandler.ProxyHandler)
-	return nil
+	a.router.HandleFunc("/v2/_bulk/delete", a.tags.BulkDeleteHandler).Methods("POST")
+	a.router.HandleFunc("/v2/_promote", a.promote.PromoteHandler).Methods("POST")
+	a.router.HandleFunc("/v2/_promote/audit", a.promote.AuditHandler).Methods("GET")
+
+	a.router.HandleFunc("/v2/retention", a.retention.RetentionGetHandler).Methods("GET")
+	a.router.HandleFunc("/v2/retention", a.retention.RetentionDeleteHandler).Methods("DELETE")
+
+	a.router.HandleFunc("/v2/_untagged", a.untagged.UntaggedListHandler).Methods("GET")
+	a.router.HandleFunc(repoRoute("untagged"), validateName(a.untagged.UntaggedGetHandler)).Methods("GET")
+	a.router.HandleFunc(repoRoute("untagged"), validateName(a.untagged.UntaggedDeleteHandler)).Methods("DELETE")
+
+	a.router.HandleFunc("/v2/_quotas", a.quota.QuotasGetHandler).Methods("GET")
+	a.router.HandleFunc(repoRoute("quota"), validateName(a.quota.RepositoryQuotaGetHandler)).Methods("GET")
+
+	a.router.PathPrefix("/").Handler(a.metrics.InstrumentProxy(a.quota.Enforce(http.HandlerFunc(a.api.ProxyHandler))))
 }
@@ -1,16 +1,33 @@
 package agent
 
+import "registry-cleaner-agent/internal/pkg/quota"
+
 type Config struct {
-	BindAddr              string   `toml:"bind_addr"`
-	CorsAllowedOrigins    []string `toml:"cors_allowed_origins"`
-	CorsAllowedHeaders    []string `toml:"cors_allowed_headers"`
-	CorsExposedHeaders    []string `toml:"cors_exposed_headers"`
-	BitCaskStoragePath    string   `toml:"bitcask_storage_path"`
-	GCIndexSchedule       string   `toml:"gc_index_schedule"`
-	GCRemovalSchedule     string   `toml:"gc_removal_schedule"`
-	ApiUrl                string   `toml:"registry_api_url"`
-	ContainerName         string   `toml:"registry_container_name"`
-	ReadonlyContainerName string   `toml:"registry_readonly_container_name"`
-	RegistryMountPoint    string   `toml:"registry_mount_point"`
-	RegistryConfig        string   `toml:"registry_config_path"`
+	BindAddr               string       `toml:"bind_addr"`
+	CorsAllowedOrigins     []string     `toml:"cors_allowed_origins"`
+	CorsAllowedHeaders     []string     `toml:"cors_allowed_headers"`
+	CorsExposedHeaders     []string     `toml:"cors_exposed_headers"`
+	BitCaskStoragePath     string       `toml:"bitcask_storage_path"`
+	GCIndexSchedule        string       `toml:"gc_index_schedule"`
+	GCRemovalSchedule      string       `toml:"gc_removal_schedule"`
+	GCHistoryRetentionDays int          `toml:"gc_history_retention_days"`
+	GCHistoryMaxEntries    int          `toml:"gc_history_max_entries"`
+	ApiUrl                 string       `toml:"registry_api_url"`
+	ContainerName          string       `toml:"registry_container_name"`
+	ReadonlyContainerName  string       `toml:"registry_readonly_container_name"`
+	RegistryMountPoint     string       `toml:"registry_mount_point"`
+	RegistryConfig         string       `toml:"registry_config_path"`
+	RetentionSchedule      string       `toml:"retention_schedule"`
+	RetentionUnpulledDays  int          `toml:"retention_unpulled_days"`
+	Quotas                 []quota.Rule `toml:"quota"`
+	DiskHighWatermark      float64      `toml:"disk_high_watermark"`
+	DiskLowWatermark       float64      `toml:"disk_low_watermark"`
+	DiskCheckInterval      string       `toml:"disk_check_interval"`
+	DiskRoundInterval      string       `toml:"disk_round_interval"`
+	DiskMinFreed           float64      `toml:"disk_min_freed"`
+	SummaryCachePersistent bool         `toml:"summary_cache_persistent"`
+	AuditRetentionDays     int          `toml:"audit_retention_days"`
+	AuditMaxEntries        int          `toml:"audit_max_entries"`
+	ImportMaxSize          string       `toml:"import_max_size"`
+	UntaggedMinAge         string       `toml:"untagged_min_age"`
 }
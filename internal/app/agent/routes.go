@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"github.com/docker/distribution/reference"
+	"github.com/gorilla/mux"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+)
+
+// Route variables follow the distribution grammar, so repository names may span several path segments
+var (
+	repoPattern      = "{repo:" + reference.NameRegexp.String() + "}"
+	tagPattern       = "{tag:" + reference.TagRegexp.String() + "}"
+	digestPattern    = "{digest:" + reference.DigestRegexp.String() + "}"
+	referencePattern = "{tag:(?:" + reference.TagRegexp.String() + "|" + reference.DigestRegexp.String() + ")}"
+)
+
+// repoRoute builds an agent route for the repository, suffix elements are joined with slashes
+func repoRoute(suffix ...string) string {
+	route := "/v2/" + repoPattern
+	for _, elem := range suffix {
+		route += "/" + elem
+	}
+	return route
+}
+
+// validateName rejects repository names the registry would refuse before reaching handlers:
+// names exceeding the length limit or not matching the whole distribution name grammar
+func validateName(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := reference.WithName(mux.Vars(r)["repo"]); err != nil {
+			agent_errors.WriteRegistryError(w, http.StatusBadRequest, agent_errors.CodeNameInvalid, err.Error(), nil)
+			return
+		}
+		next(w, r)
+	}
+}
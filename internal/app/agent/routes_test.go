@@ -0,0 +1,168 @@
+package agent
+
+import (
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"registry-cleaner-agent/internal/pkg/garbage_collector"
+	"registry-cleaner-agent/internal/pkg/metrics"
+	"registry-cleaner-agent/internal/pkg/oci_layout"
+	"registry-cleaner-agent/internal/pkg/promote"
+	"registry-cleaner-agent/internal/pkg/quota"
+	"registry-cleaner-agent/internal/pkg/registry_api"
+	"registry-cleaner-agent/internal/pkg/retention"
+	"registry-cleaner-agent/internal/pkg/search"
+	"registry-cleaner-agent/internal/pkg/status"
+	"registry-cleaner-agent/internal/pkg/storage_index"
+	"registry-cleaner-agent/internal/pkg/tags"
+	"registry-cleaner-agent/internal/pkg/untagged"
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// newTestRouter registers routes over handlers which are never invoked, requests are only matched
+func newTestRouter(t *testing.T) *mux.Router {
+	stm, err := status.InitStatusManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stm.Shutdown() })
+	m, err := metrics.InitMetrics(stm, func() bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Agent{
+		router:    mux.NewRouter(),
+		api:       &registry_api.RegistryApiHandler{},
+		gc:        &garbage_collector.GCHandler{},
+		retention: &retention.Handler{},
+		quota:     &quota.Handler{},
+		untagged:  &untagged.Handler{},
+		index:     &storage_index.Handler{},
+		tags:      &tags.Handler{},
+		search:    &search.Handler{},
+		promote:   &promote.Handler{},
+		layout:    &oci_layout.Handler{},
+		metrics:   m,
+	}
+	a.registerRoutes()
+	return a.router
+}
+
+func TestRoutes(t *testing.T) {
+	router := newTestRouter(t)
+	proxy := "/"
+	tests := []struct {
+		method   string
+		path     string
+		template string
+		vars     map[string]string
+	}{
+		{"GET", "/v2/a/b/c/d/manifests/1.0/summary", repoRoute("manifests", referencePattern, "summary"),
+			map[string]string{"repo": "a/b/c/d", "tag": "1.0"}},
+		{"HEAD", "/v2/a/b/c/d/manifests/" + testDigest + "/summary", repoRoute("manifests", referencePattern, "summary"),
+			map[string]string{"repo": "a/b/c/d", "tag": testDigest}},
+		{"GET", "/v2/a/b/c/d/manifests/latest/config", repoRoute("manifests", referencePattern, "config"),
+			map[string]string{"repo": "a/b/c/d", "tag": "latest"}},
+		{"GET", "/v2/a/b/c/d/manifests/" + testDigest + "/tags", repoRoute("manifests", digestPattern, "tags"),
+			map[string]string{"repo": "a/b/c/d", "digest": testDigest}},
+		{"GET", "/v2/a/b/c/d/tags/summary", repoRoute("tags", "summary"),
+			map[string]string{"repo": "a/b/c/d"}},
+		{"GET", "/v2/a/b/c/d/tags/1.0/stats", repoRoute("tags", tagPattern, "stats"),
+			map[string]string{"repo": "a/b/c/d", "tag": "1.0"}},
+		{"DELETE", "/v2/a/b/c/d/tags/1.0", repoRoute("tags", tagPattern),
+			map[string]string{"repo": "a/b/c/d", "tag": "1.0"}},
+		{"GET", "/v2/a/b/c/d/untagged", repoRoute("untagged"),
+			map[string]string{"repo": "a/b/c/d"}},
+		{"GET", "/v2/a/b/c/d/quota", repoRoute("quota"),
+			map[string]string{"repo": "a/b/c/d"}},
+
+		// Names colliding with agent route words
+		{"GET", "/v2/tags/tags/summary", repoRoute("tags", "summary"),
+			map[string]string{"repo": "tags"}},
+		{"GET", "/v2/team/tags/tags/summary", repoRoute("tags", "summary"),
+			map[string]string{"repo": "team/tags"}},
+		{"GET", "/v2/team/tags/summary/tags/summary", repoRoute("tags", "summary"),
+			map[string]string{"repo": "team/tags/summary"}},
+		{"DELETE", "/v2/team/tags/tags/summary", repoRoute("tags", tagPattern),
+			map[string]string{"repo": "team/tags", "tag": "summary"}},
+		{"GET", "/v2/summary/manifests/summary/summary", repoRoute("manifests", referencePattern, "summary"),
+			map[string]string{"repo": "summary", "tag": "summary"}},
+		{"GET", "/v2/manifests/manifests/manifests/config", repoRoute("manifests", referencePattern, "config"),
+			map[string]string{"repo": "manifests", "tag": "manifests"}},
+		{"GET", "/v2/team/manifests/manifests/latest/export", repoRoute("manifests", referencePattern, "export"),
+			map[string]string{"repo": "team/manifests", "tag": "latest"}},
+		{"GET", "/v2/diff/diff", repoRoute("diff"),
+			map[string]string{"repo": "diff"}},
+		{"GET", "/v2/team/diff/diff", repoRoute("diff"),
+			map[string]string{"repo": "team/diff"}},
+		{"POST", "/v2/import/import", repoRoute("import"),
+			map[string]string{"repo": "import"}},
+		{"POST", "/v2/team/import/import", repoRoute("import"),
+			map[string]string{"repo": "team/import"}},
+
+		// Agent endpoints outside repositories
+		{"GET", "/v2/_catalog/summary", "/v2/_catalog/summary", map[string]string{}},
+		{"GET", "/v2/_blobs/" + testDigest + "/references", "/v2/_blobs/" + digestPattern + "/references",
+			map[string]string{"digest": testDigest}},
+		{"GET", "/v2/garbage/history", "/v2/garbage/history", map[string]string{}},
+
+		// Registry API passes through
+		{"GET", "/v2/a/b/c/d/manifests/latest", proxy, map[string]string{}},
+		{"PUT", "/v2/team/tags/manifests/summary", proxy, map[string]string{}},
+		{"GET", "/v2/team/diff/tags/list", proxy, map[string]string{}},
+		{"PATCH", "/v2/import/blobs/uploads/1234", proxy, map[string]string{}},
+		{"GET", "/v2/Team/tags/summary", proxy, map[string]string{}},
+		{"GET", "/v2/_catalog", proxy, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			var match mux.RouteMatch
+			if !router.Match(httptest.NewRequest(tt.method, tt.path, nil), &match) || match.Route == nil {
+				t.Fatal("no route matched")
+			}
+			template, err := match.Route.GetPathTemplate()
+			if err != nil {
+				template = "/"
+			}
+			if template != tt.template {
+				t.Errorf("matched %q, want %q", template, tt.template)
+			}
+			if len(match.Vars) != len(tt.vars) {
+				t.Errorf("vars %v, want %v", match.Vars, tt.vars)
+			}
+			for key, want := range tt.vars {
+				if got := match.Vars[key]; got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name string
+		want int
+	}{
+		{"team/service/api", http.StatusOK},
+		{"a/b/c/d", http.StatusOK},
+		{strings.Repeat("a", 256), http.StatusBadRequest},
+		{"team//api", http.StatusBadRequest},
+		{"team/API", http.StatusBadRequest},
+		{"-team", http.StatusBadRequest},
+	}
+	handler := validateName(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	for _, tt := range tests {
+		r := mux.SetURLVars(httptest.NewRequest("GET", "/", nil), map[string]string{"repo": tt.name})
+		w := httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%q: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package agent_errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Error codes defined by the distribution spec
+const (
+	CodeDenied          = "DENIED"
+	CodeNameInvalid     = "NAME_INVALID"
+	CodeNameUnknown     = "NAME_UNKNOWN"
+	CodeManifestUnknown = "MANIFEST_UNKNOWN"
+)
+
+//easyjson:json
+type RegistryError struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Detail  interface{} `json:"detail,omitempty"`
+}
+
+//easyjson:json
+type RegistryErrors struct {
+	Errors []RegistryError `json:"errors"`
+}
+
+// WriteRegistryError replies with an error body clients of the Registry API understand
+func WriteRegistryError(w http.ResponseWriter, status int, code string, message string, detail interface{}) {
+	res, err := json.Marshal(&RegistryErrors{
+		Errors: []RegistryError{{Code: code, Message: message, Detail: detail}},
+	})
+	if err != nil {
+		http.Error(w, message, status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(res)
+}
@@ -0,0 +1,209 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package agent_errors
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonBb596907DecodeRegistryCleanerAgentInternalPkgAgentErrors(in *jlexer.Lexer, out *RegistryErrors) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "errors":
+			if in.IsNull() {
+				in.Skip()
+				out.Errors = nil
+			} else {
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]RegistryError, 0, 1)
+					} else {
+						out.Errors = []RegistryError{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 RegistryError
+					(v1).UnmarshalEasyJSON(in)
+					out.Errors = append(out.Errors, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBb596907EncodeRegistryCleanerAgentInternalPkgAgentErrors(out *jwriter.Writer, in RegistryErrors) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix[1:])
+		if in.Errors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Errors {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegistryErrors) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBb596907EncodeRegistryCleanerAgentInternalPkgAgentErrors(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegistryErrors) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBb596907EncodeRegistryCleanerAgentInternalPkgAgentErrors(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegistryErrors) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBb596907DecodeRegistryCleanerAgentInternalPkgAgentErrors(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegistryErrors) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBb596907DecodeRegistryCleanerAgentInternalPkgAgentErrors(l, v)
+}
+func easyjsonBb596907DecodeRegistryCleanerAgentInternalPkgAgentErrors1(in *jlexer.Lexer, out *RegistryError) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "detail":
+			if m, ok := out.Detail.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Detail.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Detail = in.Interface()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBb596907EncodeRegistryCleanerAgentInternalPkgAgentErrors1(out *jwriter.Writer, in RegistryError) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if in.Detail != nil {
+		const prefix string = ",\"detail\":"
+		out.RawString(prefix)
+		if m, ok := in.Detail.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Detail.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Detail))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegistryError) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBb596907EncodeRegistryCleanerAgentInternalPkgAgentErrors1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegistryError) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBb596907EncodeRegistryCleanerAgentInternalPkgAgentErrors1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegistryError) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBb596907DecodeRegistryCleanerAgentInternalPkgAgentErrors1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegistryError) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBb596907DecodeRegistryCleanerAgentInternalPkgAgentErrors1(l, v)
+}
@@ -0,0 +1,193 @@
+package disk_monitor
+
+import (
+	"errors"
+	"log"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/garbage_collector"
+	"registry-cleaner-agent/internal/pkg/retention"
+	"registry-cleaner-agent/internal/pkg/status"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Watermarks are percentages of used space on the registry storage
+type Watermarks struct {
+	// High starts out of schedule cleanup, zero disables the monitor
+	High float64
+	// Low stops cleanup rounds once usage falls below it
+	Low float64
+}
+
+const (
+	ResultInProgress = "in_progress"
+	ResultResolved   = "resolved"
+	ResultExhausted  = "exhausted"
+
+	// ExhaustedCooldown postpones the next trigger when a cleanup round freed less than MinFreed
+	ExhaustedCooldown = time.Hour
+	// DefaultRoundInterval is the minimum time between cleanup rounds, each one makes the registry read-only
+	DefaultRoundInterval = 15 * time.Minute
+	// DefaultMinFreed is the usage in percentage points a cleanup round has to free for another round
+	DefaultMinFreed = 1.0
+)
+
+var ErrInvalidWatermarks = errors.New("disk watermarks must satisfy 0 < low < high <= 100")
+
+type Monitor struct {
+	MountPoint    string
+	Watermarks    Watermarks
+	Interval      time.Duration
+	Retention     *retention.Handler
+	GC            *garbage_collector.GCHandler
+	StatusManager *status.Manager
+	// RoundInterval is the minimum time between cleanup rounds
+	RoundInterval time.Duration
+	// MinFreed is the usage in percentage points a round has to free, otherwise cleanup stops as exhausted
+	MinFreed float64
+	// mu guards the cleanup state below, rounds never overlap
+	mu            *sync.Mutex
+	active        bool
+	lastRoundAt   time.Time
+	cooldownUntil time.Time
+	stop          chan struct{}
+	wg            *sync.WaitGroup
+}
+
+func InitDiskMonitor(
+	mountPoint string, watermarks Watermarks, interval time.Duration,
+	rh *retention.Handler, gch *garbage_collector.GCHandler, stm *status.Manager) (*Monitor, error) {
+	if rh == nil || gch == nil || stm == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	if watermarks.High != 0 && (watermarks.Low <= 0 || watermarks.Low >= watermarks.High || watermarks.High > 100) {
+		return nil, ErrInvalidWatermarks
+	}
+	return &Monitor{
+		MountPoint:    mountPoint,
+		Watermarks:    watermarks,
+		Interval:      interval,
+		Retention:     rh,
+		GC:            gch,
+		StatusManager: stm,
+		RoundInterval: DefaultRoundInterval,
+		MinFreed:      DefaultMinFreed,
+		mu:            &sync.Mutex{},
+		stop:          make(chan struct{}),
+		wg:            &sync.WaitGroup{},
+	}, nil
+}
+
+// Usage returns the used percentage of the filesystem the same way df does
+func Usage(mountPoint string) (float64, error) {
+	var st syscall.Statfs_t
+	err := syscall.Statfs(mountPoint, &st)
+	if err != nil {
+		return 0, err
+	}
+	used := (uint64(st.Blocks) - uint64(st.Bfree)) * uint64(st.Bsize)
+	available := uint64(st.Bavail) * uint64(st.Bsize)
+	if used+available == 0 {
+		return 0, nil
+	}
+	return float64(used) / float64(used+available) * 100, nil
+}
+
+func (m *Monitor) Start() {
+	if m.Watermarks.High == 0 {
+		return
+	}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(m.Interval)
+		defer ticker.Stop()
+		for {
+			m.check()
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for a running cleanup round to finish
+func (m *Monitor) Stop() {
+	close(m.stop)
+	m.wg.Wait()
+}
+
+func (m *Monitor) check() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	usage, err := Usage(m.MountPoint)
+	if err != nil {
+		log.Printf("[ERROR at disk_monitor.Monitor.check]: %v", err)
+		return
+	}
+	m.StatusManager.SetDiskUsage(usage)
+	if m.active && usage < m.Watermarks.Low {
+		m.finish(ResultResolved, usage)
+		return
+	}
+	now := time.Now()
+	if !m.active {
+		if usage < m.Watermarks.High || now.Before(m.cooldownUntil) {
+			return
+		}
+		m.trigger(usage)
+	} else if now.Sub(m.lastRoundAt) < m.RoundInterval {
+		return
+	}
+	m.lastRoundAt = now
+	freed := m.cleanupRound(usage)
+	if !freed {
+		m.finish(ResultExhausted, usage)
+		m.cooldownUntil = time.Now().Add(ExhaustedCooldown)
+	}
+}
+
+func (m *Monitor) trigger(usage float64) {
+	log.Printf("[INFO at disk_monitor.Monitor.trigger]: disk usage %.2f%% above high watermark %.2f%%",
+		usage, m.Watermarks.High)
+	m.active = true
+	now := time.Now()
+	result := ResultInProgress
+	_ = m.StatusManager.UpdateStatus(&status.Update{
+		DiskCleanupTriggeredAt:  &now,
+		DiskCleanupTriggerUsage: &usage,
+		DiskCleanupResult:       &result,
+	})
+}
+
+func (m *Monitor) finish(result string, usage float64) {
+	log.Printf("[INFO at disk_monitor.Monitor.finish]: cleanup %s, disk usage %.2f%%", result, usage)
+	m.active = false
+	_ = m.StatusManager.UpdateStatus(&status.Update{DiskCleanupResult: &result})
+}
+
+// cleanupRound applies retention and removes garbage, reports whether at least MinFreed was freed
+func (m *Monitor) cleanupRound(usageBefore float64) bool {
+	report, err := m.Retention.Apply()
+	if err != nil && !errors.Is(err, retention.ErrDisabled) {
+		log.Printf("[ERROR at disk_monitor.Monitor.cleanupRound]: retention: %v", err)
+	}
+	if report != nil {
+		log.Printf("[INFO at disk_monitor.Monitor.cleanupRound]: retention deleted %d manifests", report.Deleted)
+	}
+	m.GC.RemoveGarbage(garbage_collector.TriggerDisk)
+	usage, err := Usage(m.MountPoint)
+	if err != nil {
+		log.Printf("[ERROR at disk_monitor.Monitor.cleanupRound]: %v", err)
+		return false
+	}
+	m.StatusManager.SetDiskUsage(usage)
+	if usage < m.Watermarks.Low {
+		m.finish(ResultResolved, usage)
+		return true
+	}
+	return usageBefore-usage >= m.MinFreed
+}
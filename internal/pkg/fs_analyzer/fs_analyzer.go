@@ -1,10 +1,13 @@
 package fs_analyzer
 
 import (
+	"errors"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
 	"strings"
+	"time"
 )
 
 type Analyzer struct {
@@ -14,6 +17,8 @@ type Analyzer struct {
 const (
 	BlobsPath    = "docker/registry/v2/blobs"
 	BlobFilename = "data"
+	// MaxReadableBlobSize matches the manifest size limit of the registry
+	MaxReadableBlobSize = 4 << 20
 )
 
 func NewFSAnalyzer(registryMntRoot string) *Analyzer {
@@ -22,12 +27,27 @@ func NewFSAnalyzer(registryMntRoot string) *Analyzer {
 	}
 }
 
-func (a *Analyzer) GetBlobSize(digest string) (int64, error) {
+var (
+	ErrInvalidDigest = errors.New("invalid blob digest")
+	ErrBlobTooLarge  = errors.New("blob is too large to be read into memory")
+)
+
+func (a *Analyzer) blobPath(digest string) (string, error) {
 	sInd := strings.IndexRune(digest, ':')
+	if sInd < 0 || len(digest)-sInd-1 < 2 || strings.ContainsAny(digest, "/.") {
+		return "", ErrInvalidDigest
+	}
 	digestType := digest[:sInd] // sha:
 	rawDigest := digest[sInd+1:]
 	prefix := rawDigest[:2] // two leading digest symbols
-	blobPath := path.Join(a.mntRoot, BlobsPath, digestType, prefix, rawDigest, BlobFilename)
+	return path.Join(a.mntRoot, BlobsPath, digestType, prefix, rawDigest, BlobFilename), nil
+}
+
+func (a *Analyzer) GetBlobSize(digest string) (int64, error) {
+	blobPath, err := a.blobPath(digest)
+	if err != nil {
+		return 0, err
+	}
 	blob, err := os.Stat(blobPath)
 	if err != nil {
 		return 0, err
@@ -35,6 +55,35 @@ func (a *Analyzer) GetBlobSize(digest string) (int64, error) {
 	return blob.Size(), nil
 }
 
+// GetBlobModTime returns the time the blob was written to the storage
+func (a *Analyzer) GetBlobModTime(digest string) (time.Time, error) {
+	blobPath, err := a.blobPath(digest)
+	if err != nil {
+		return time.Time{}, err
+	}
+	blob, err := os.Stat(blobPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return blob.ModTime(), nil
+}
+
+// GetExistingBlobsSize sums sizes of the blobs present on disk, missing blobs are ignored
+func (a *Analyzer) GetExistingBlobsSize(digests []string) (int64, error) {
+	total := int64(0)
+	for _, digest := range digests {
+		size, err := a.GetBlobSize(digest)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += size
+	}
+	return total, nil
+}
+
 // GetBlobsSize TODO: batch check (goroutines)
 func (a *Analyzer) GetBlobsSize(digests []string) (sizes []int64, total int64, err error) {
 	sizes = make([]int64, len(digests))
@@ -53,3 +102,40 @@ func (a *Analyzer) GetBlobsSize(digests []string) (sizes []int64, total int64, e
 	}
 	return sizes, total, err
 }
+
+// RemoveBlobs deletes blob directories as the registry garbage collector does and returns digests of removed
+// blobs, it stops at the first failure; blobs missing already are skipped
+func (a *Analyzer) RemoveBlobs(digests []string) ([]string, error) {
+	removed := make([]string, 0, len(digests))
+	for _, digest := range digests {
+		blobPath, err := a.blobPath(digest)
+		if err != nil {
+			return removed, err
+		}
+		blobDir := path.Dir(blobPath)
+		if _, err = os.Stat(blobDir); os.IsNotExist(err) {
+			continue
+		}
+		if err = os.RemoveAll(blobDir); err != nil {
+			return removed, err
+		}
+		removed = append(removed, digest)
+	}
+	return removed, nil
+}
+
+// ReadBlob returns content of small blobs such as manifests and image configs
+func (a *Analyzer) ReadBlob(digest string) ([]byte, error) {
+	blobPath, err := a.blobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := os.Stat(blobPath)
+	if err != nil {
+		return nil, err
+	}
+	if blob.Size() > MaxReadableBlobSize {
+		return nil, ErrBlobTooLarge
+	}
+	return ioutil.ReadFile(blobPath)
+}
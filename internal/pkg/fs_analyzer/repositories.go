@@ -0,0 +1,195 @@
+package fs_analyzer
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	RepositoriesPath  = "docker/registry/v2/repositories"
+	LayersDir         = "_layers"
+	ManifestsDir      = "_manifests"
+	UploadsDir        = "_uploads"
+	RevisionsDir      = "revisions"
+	TagsDir           = "tags"
+	CurrentTagDir     = "current"
+	LinkFilename      = "link"
+	UploadDataFile    = "data"
+	repoMetaDirPrefix = "_"
+)
+
+func (a *Analyzer) repositoryPath(repo string, elem ...string) string {
+	return path.Join(append([]string{a.mntRoot, RepositoriesPath, repo}, elem...)...)
+}
+
+func readLink(linkPath string) (string, error) {
+	data, err := ioutil.ReadFile(linkPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// ListRepositories walks the repositories tree; nested names are returned slash-separated
+func (a *Analyzer) ListRepositories() ([]string, error) {
+	root := path.Join(a.mntRoot, RepositoriesPath)
+	var repositories []string
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !info.IsDir() || p == root {
+			return nil
+		}
+		name := info.Name()
+		if strings.HasPrefix(name, repoMetaDirPrefix) {
+			if name == LayersDir || name == ManifestsDir {
+				repo, _ := filepath.Rel(root, filepath.Dir(p))
+				repositories = append(repositories, filepath.ToSlash(repo))
+			}
+			// Registry metadata directories never contain nested repositories
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(repositories)
+	return dedupSorted(repositories), nil
+}
+
+// RepositoryExists reports whether the registry storage holds the repository
+func (a *Analyzer) RepositoryExists(repo string) (bool, error) {
+	for _, dir := range []string{ManifestsDir, LayersDir} {
+		info, err := os.Stat(a.repositoryPath(repo, dir))
+		if err == nil && info.IsDir() {
+			return true, nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func dedupSorted(values []string) []string {
+	res := values[:0]
+	for i, v := range values {
+		if i == 0 || values[i-1] != v {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// listLinks reads every <algorithm>/<hex>/link under dir, dangling directories are skipped
+func listLinks(dir string) ([]string, error) {
+	algorithms, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var digests []string
+	for _, algorithm := range algorithms {
+		hashes, err := ioutil.ReadDir(path.Join(dir, algorithm.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, hash := range hashes {
+			digest, err := readLink(path.Join(dir, algorithm.Name(), hash.Name(), LinkFilename))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			digests = append(digests, digest)
+		}
+	}
+	return digests, nil
+}
+
+// ListLayerLinks returns digests of layer and config blobs linked to the repository
+func (a *Analyzer) ListLayerLinks(repo string) ([]string, error) {
+	return listLinks(a.repositoryPath(repo, LayersDir))
+}
+
+// ListManifestRevisions returns digests of every manifest stored in the repository
+func (a *Analyzer) ListManifestRevisions(repo string) ([]string, error) {
+	return listLinks(a.repositoryPath(repo, ManifestsDir, RevisionsDir))
+}
+
+// GetManifestRevisionModTime returns the time the manifest revision was linked to the repository
+func (a *Analyzer) GetManifestRevisionModTime(repo, digest string) (time.Time, error) {
+	sInd := strings.IndexRune(digest, ':')
+	if sInd < 0 || strings.ContainsAny(digest, "/.") {
+		return time.Time{}, ErrInvalidDigest
+	}
+	info, err := os.Stat(a.repositoryPath(repo, ManifestsDir, RevisionsDir, digest[:sInd], digest[sInd+1:], LinkFilename))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// ListTags maps tag names to the digest they currently point at
+func (a *Analyzer) ListTags(repo string) (map[string]string, error) {
+	tagsDir := a.repositoryPath(repo, ManifestsDir, TagsDir)
+	entries, err := ioutil.ReadDir(tagsDir)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		digest, err := readLink(path.Join(tagsDir, entry.Name(), CurrentTagDir, LinkFilename))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tags[entry.Name()] = digest
+	}
+	return tags, nil
+}
+
+// ListRepositoryBlobs returns unique digests of layers, configs and manifests held by the repository
+func (a *Analyzer) ListRepositoryBlobs(repo string) ([]string, error) {
+	layers, err := a.ListLayerLinks(repo)
+	if err != nil {
+		return nil, err
+	}
+	manifests, err := a.ListManifestRevisions(repo)
+	if err != nil {
+		return nil, err
+	}
+	blobs := append(layers, manifests...)
+	sort.Strings(blobs)
+	return dedupSorted(blobs), nil
+}
+
+// GetUploadSize returns the number of bytes received so far by the blob upload session
+func (a *Analyzer) GetUploadSize(repo, uuid string) (int64, error) {
+	if uuid == "" || strings.ContainsAny(uuid, "/\\") || uuid == "." || uuid == ".." {
+		return 0, os.ErrNotExist
+	}
+	info, err := os.Stat(a.repositoryPath(repo, UploadsDir, uuid, UploadDataFile))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
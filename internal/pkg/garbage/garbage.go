@@ -4,15 +4,30 @@ package garbage
 type GarbageBlob struct {
 	Size   int64  `json:"size"`
 	Digest string `json:"digest"`
+	// Kind is layer, config or manifest
+	Kind string `json:"kind"`
+	// Repositories still hold _layers links to the blob
+	Repositories []string `json:"repositories"`
+	ModifiedAt   string   `json:"modifiedAt"`
+}
+
+//easyjson:json
+type RepositoryGarbage struct {
+	Name      string `json:"name"`
+	Blobs     int    `json:"blobs"`
+	TotalSize int64  `json:"totalSize"`
 }
 
 //easyjson:json
 type Garbage struct {
 	Blobs []GarbageBlob `json:"blobs"`
+	// Repositories aggregate garbage blobs by linking repository, largest first
+	Repositories []RepositoryGarbage `json:"repositories"`
 }
 
 func New() *Garbage {
 	return &Garbage{
-		Blobs: []GarbageBlob{},
+		Blobs:        []GarbageBlob{},
+		Repositories: []RepositoryGarbage{},
 	}
 }
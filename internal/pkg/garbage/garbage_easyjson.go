@@ -17,7 +17,87 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(in *jlexer.Lexer, out *GarbageBlob) {
+func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(in *jlexer.Lexer, out *RepositoryGarbage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "blobs":
+			out.Blobs = int(in.Int())
+		case "totalSize":
+			out.TotalSize = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(out *jwriter.Writer, in RepositoryGarbage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"blobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.Blobs))
+	}
+	{
+		const prefix string = ",\"totalSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepositoryGarbage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepositoryGarbage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepositoryGarbage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepositoryGarbage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(l, v)
+}
+func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(in *jlexer.Lexer, out *GarbageBlob) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -40,6 +120,33 @@ func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(in *jlexer.Lex
 			out.Size = int64(in.Int64())
 		case "digest":
 			out.Digest = string(in.String())
+		case "kind":
+			out.Kind = string(in.String())
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Repositories = append(out.Repositories, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "modifiedAt":
+			out.ModifiedAt = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -50,7 +157,7 @@ func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(in *jlexer.Lex
 		in.Consumed()
 	}
 }
-func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(out *jwriter.Writer, in GarbageBlob) {
+func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(out *jwriter.Writer, in GarbageBlob) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -64,33 +171,59 @@ func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(out *jwriter.W
 		out.RawString(prefix)
 		out.String(string(in.Digest))
 	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Repositories {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"modifiedAt\":"
+		out.RawString(prefix)
+		out.String(string(in.ModifiedAt))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v GarbageBlob) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(&w, v)
+	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v GarbageBlob) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage(w, v)
+	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *GarbageBlob) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(&r, v)
+	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *GarbageBlob) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage(l, v)
+	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(l, v)
 }
-func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(in *jlexer.Lexer, out *Garbage) {
+func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage2(in *jlexer.Lexer, out *Garbage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -117,7 +250,7 @@ func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(in *jlexer.Le
 				in.Delim('[')
 				if out.Blobs == nil {
 					if !in.IsDelim(']') {
-						out.Blobs = make([]GarbageBlob, 0, 2)
+						out.Blobs = make([]GarbageBlob, 0, 0)
 					} else {
 						out.Blobs = []GarbageBlob{}
 					}
@@ -125,9 +258,32 @@ func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(in *jlexer.Le
 					out.Blobs = (out.Blobs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 GarbageBlob
-					(v1).UnmarshalEasyJSON(in)
-					out.Blobs = append(out.Blobs, v1)
+					var v4 GarbageBlob
+					(v4).UnmarshalEasyJSON(in)
+					out.Blobs = append(out.Blobs, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]RepositoryGarbage, 0, 2)
+					} else {
+						out.Repositories = []RepositoryGarbage{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 RepositoryGarbage
+					(v5).UnmarshalEasyJSON(in)
+					out.Repositories = append(out.Repositories, v5)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -142,7 +298,7 @@ func easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(out *jwriter.Writer, in Garbage) {
+func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage2(out *jwriter.Writer, in Garbage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -153,11 +309,27 @@ func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(out *jwriter.
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.Blobs {
-				if v2 > 0 {
+			for v6, v7 := range in.Blobs {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				(v7).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Repositories {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				(v3).MarshalEasyJSON(out)
+				(v9).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -168,23 +340,23 @@ func easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v Garbage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(&w, v)
+	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Garbage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage1(w, v)
+	easyjson5d95a6ebEncodeRegistryCleanerAgentInternalPkgGarbage2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Garbage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(&r, v)
+	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Garbage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage1(l, v)
+	easyjson5d95a6ebDecodeRegistryCleanerAgentInternalPkgGarbage2(l, v)
 }
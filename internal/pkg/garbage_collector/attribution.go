@@ -0,0 +1,79 @@
+package garbage_collector
+
+import (
+	"log"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/garbage"
+	"registry-cleaner-agent/internal/pkg/storage_index"
+	"sort"
+	"time"
+)
+
+// layerLinks maps blob digests to repositories holding _layers links to them
+func layerLinks(fsa *fs_analyzer.Analyzer) (map[string][]string, error) {
+	repositories, err := fsa.ListRepositories()
+	if err != nil {
+		return nil, err
+	}
+	links := make(map[string][]string)
+	for _, repo := range repositories {
+		digests, err := fsa.ListLayerLinks(repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, digest := range digests {
+			links[digest] = append(links[digest], repo)
+		}
+	}
+	return links, nil
+}
+
+// Attribute describes garbage blobs with their kind, linking repositories and modification time
+func Attribute(fsa *fs_analyzer.Analyzer, blobs []string, sizes []int64) (*garbage.Garbage, error) {
+	links, err := layerLinks(fsa)
+	if err != nil {
+		return nil, err
+	}
+	report := garbage.New()
+	byRepository := make(map[string]*garbage.RepositoryGarbage)
+	for i, digest := range blobs {
+		blob := garbage.GarbageBlob{
+			Size:         sizes[i],
+			Digest:       digest,
+			Repositories: links[digest],
+		}
+		if blob.Repositories == nil {
+			blob.Repositories = []string{}
+		}
+		blob.Kind, err = storage_index.DetectKind(fsa, digest)
+		if err != nil {
+			log.Printf("[ERROR at garbage_collector.Attribute]: %v", err)
+		}
+		modTime, err := fsa.GetBlobModTime(digest)
+		if err != nil {
+			log.Printf("[ERROR at garbage_collector.Attribute]: %v", err)
+		} else {
+			blob.ModifiedAt = modTime.Format(time.RFC3339)
+		}
+		for _, repo := range blob.Repositories {
+			aggregate, ok := byRepository[repo]
+			if !ok {
+				aggregate = &garbage.RepositoryGarbage{Name: repo}
+				byRepository[repo] = aggregate
+			}
+			aggregate.Blobs++
+			aggregate.TotalSize += blob.Size
+		}
+		report.Blobs = append(report.Blobs, blob)
+	}
+	for _, aggregate := range byRepository {
+		report.Repositories = append(report.Repositories, *aggregate)
+	}
+	sort.Slice(report.Repositories, func(i, j int) bool {
+		if report.Repositories[i].TotalSize != report.Repositories[j].TotalSize {
+			return report.Repositories[i].TotalSize > report.Repositories[j].TotalSize
+		}
+		return report.Repositories[i].Name < report.Repositories[j].Name
+	})
+	return report, nil
+}
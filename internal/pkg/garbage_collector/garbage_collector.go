@@ -15,14 +15,14 @@ import (
 const (
 	RegistryBin         = "/bin/registry"
 	GcCommand           = "garbage-collect"
-	DeleteUntagged      = "--delete-untagged"
 	DryRun              = "--dry-run"
 	EligibleForDeletion = "blob eligible for deletion: "
 	StatSuffix          = "manifests eligible for deletion"
-	TimePrefix          = "time="
-	LogPrefix           = "level="
 )
 
+// GarbageCollector runs the registry garbage-collect command without --delete-untagged: it would also delete
+// OCI referrers, which no tag leads to. Untagged manifests are deleted through the registry API beforehand.
+// The command only marks blobs with --dry-run, the sweep deletes them from the storage afterwards
 type GarbageCollector struct {
 	ContainerName      string
 	ROContainerName    string
@@ -34,6 +34,9 @@ var (
 	ErrAlreadyRunning = errors.New("garbage collector already running")
 )
 
+// Sweep deletes blobs eligible for deletion and returns digests of removed ones
+type Sweep func(eligible []string) ([]string, error)
+
 func NewGarbageCollector(containerName, roContainerName, registryConfigPath string) *GarbageCollector {
 	return &GarbageCollector{
 		ContainerName:      containerName,
@@ -60,15 +63,20 @@ func (gc *GarbageCollector) ListGarbageBlobs() ([]string, error) {
 
 func (gc *GarbageCollector) listGarbageBlobs() ([]string, error) {
 	defer gc.sem.Release(1)
-	cmd := exec.Command("docker", "exec", gc.ContainerName,
-		RegistryBin, GcCommand, DeleteUntagged, DryRun, gc.RegistryConfigPath)
+	return gc.dryRun(gc.ContainerName)
+}
+
+// dryRun lists blobs eligible for deletion without deleting them, the caller holds the semaphore
+func (gc *GarbageCollector) dryRun(container string) ([]string, error) {
+	cmd := exec.Command("docker", "exec", container,
+		RegistryBin, GcCommand, DryRun, gc.RegistryConfigPath)
 	var out, stderr bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
 	err := cmd.Run()
 	if err != nil {
 		logErr := fmt.Errorf("docker garbage-collect failed: %v; srderr: %s", err, stderr.String())
-		log.Printf("[ERROR at GarbageCollector.listGarbageBlobs]: %v", logErr)
+		log.Printf("[ERROR at GarbageCollector.dryRun]: %v", logErr)
 		return nil, logErr
 	}
 	sc := bufio.NewScanner(bytes.NewReader(out.Bytes()))
@@ -78,25 +86,25 @@ func (gc *GarbageCollector) listGarbageBlobs() ([]string, error) {
 		if strings.HasPrefix(line, EligibleForDeletion) {
 			blobs = append(blobs, strings.TrimPrefix(line, EligibleForDeletion))
 		} else if strings.HasSuffix(line, StatSuffix) {
-			log.Printf("[INFO at GarbageCollector.listGarbageBlobs] garbage collector dry run results: %s\n", line)
+			log.Printf("[INFO at GarbageCollector.dryRun] garbage collector dry run results: %s\n", line)
 		}
 	}
 	return blobs, nil
 }
 
-func (gc *GarbageCollector) TryRemoveGarbageBlobs() error {
+func (gc *GarbageCollector) TryRemoveGarbageBlobs(sweep Sweep) ([]string, error) {
 	if !gc.sem.TryAcquire(1) {
-		return ErrAlreadyRunning
+		return nil, ErrAlreadyRunning
 	}
-	return gc.removeGarbageBlobs()
+	return gc.removeGarbageBlobs(sweep)
 }
 
-func (gc *GarbageCollector) RemoveGarbageBlobs() error {
+func (gc *GarbageCollector) RemoveGarbageBlobs(sweep Sweep) ([]string, error) {
 	err := gc.sem.Acquire(context.Background(), 1)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return gc.removeGarbageBlobs()
+	return gc.removeGarbageBlobs(sweep)
 }
 
 func (gc *GarbageCollector) swapContainers(startRO bool) error {
@@ -129,37 +137,25 @@ func (gc *GarbageCollector) swapContainers(startRO bool) error {
 	return nil
 }
 
-func (gc *GarbageCollector) removeGarbageBlobs() error {
+// removeGarbageBlobs lists blobs eligible for deletion while the registry is read-only and they can not change,
+// and passes them to sweep, which deletes them and returns digests of removed blobs
+func (gc *GarbageCollector) removeGarbageBlobs(sweep Sweep) ([]string, error) {
 	err := gc.swapContainers(true)
 	if err != nil {
 		gc.sem.Release(1)
-		return err
+		return nil, err
 	}
-	cmd := exec.Command("docker", "exec", gc.ROContainerName,
-		RegistryBin, GcCommand, DeleteUntagged, gc.RegistryConfigPath)
-	var out, stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
-	err = cmd.Run()
-	go func() {
-		_ = gc.swapContainers(false)
-		gc.sem.Release(1)
+	defer func() {
+		go func() {
+			_ = gc.swapContainers(false)
+			gc.sem.Release(1)
+		}()
 	}()
+	eligible, err := gc.dryRun(gc.ROContainerName)
 	if err != nil {
-		logErr := fmt.Errorf("docker garbage-collect failed: %v; srderr: %s", err, stderr.String())
-		log.Printf("[ERROR at GarbageCollector.TryRemoveGarbageBlobs]: %v", logErr)
-		return logErr
-	}
-	sc := bufio.NewScanner(bytes.NewReader(out.Bytes()))
-	for sc.Scan() {
-		line := sc.Text()
-		if strings.HasPrefix(line, TimePrefix) {
-			log.Println(line[strings.Index(line, LogPrefix):])
-		} else if strings.HasSuffix(line, StatSuffix) {
-			log.Printf("[INFO at GarbageCollector.TryRemoveGarbageBlobs] garbage collector run results %s\n", line)
-		}
+		return nil, err
 	}
-	return nil
+	return sweep(eligible)
 }
 
 func (gc *GarbageCollector) Shutdown(ctx context.Context) error {
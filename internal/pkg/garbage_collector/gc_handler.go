@@ -2,14 +2,14 @@ package garbage_collector
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"github.com/robfig/cron"
 	"log"
 	"net/http"
 	"registry-cleaner-agent/internal/pkg/agent_errors"
 	"registry-cleaner-agent/internal/pkg/fs_analyzer"
-	"registry-cleaner-agent/internal/pkg/garbage"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/metrics"
 	"registry-cleaner-agent/internal/pkg/status"
 	"sync"
 	"time"
@@ -19,10 +19,39 @@ type GCHandler struct {
 	Gc            *GarbageCollector
 	StatusManager *status.Manager
 	FSAnalyzer    *fs_analyzer.Analyzer
-	cron          *cron.Cron
-	mu            *sync.RWMutex
+	// Metrics counts runs and reclaimed bytes when set
+	Metrics *metrics.Metrics
+	cron    *cron.Cron
+	mu      *sync.RWMutex
+	// HistoryRetention limits the run history kept in the status storage
+	HistoryRetention HistoryRetention
+	// Untagged deletes untagged manifests before removal runs when set, index runs count blobs they free
+	Untagged UntaggedPruner
 }
 
+// UntaggedPruner deletes untagged manifests through the registry API and returns their number;
+// UntaggedGarbage lists blobs the deletion turns into garbage
+type UntaggedPruner interface {
+	DeleteUntagged() (int, error)
+	UntaggedGarbage() ([]string, error)
+}
+
+// Run kinds and results
+const (
+	RunIndex      = "index"
+	RunRemoval    = "removal"
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultSkipped = metrics.ResultSkipped
+)
+
+// Run triggers
+const (
+	TriggerCron = "cron"
+	TriggerAPI  = "api"
+	TriggerDisk = "disk"
+)
+
 func InitGCHandler(
 	gc *GarbageCollector, stm *status.Manager,
 	fsa *fs_analyzer.Analyzer) (*GCHandler, error) {
@@ -39,11 +68,11 @@ func InitGCHandler(
 }
 
 func (gch *GCHandler) EnableCron(indexSpec string, removalSpec string) error {
-	err := gch.cron.AddFunc(indexSpec, gch.IndexGarbage)
+	err := gch.cron.AddFunc(indexSpec, func() { gch.IndexGarbage(TriggerCron) })
 	if err != nil {
 		return err
 	}
-	err = gch.cron.AddFunc(removalSpec, gch.RemoveGarbage)
+	err = gch.cron.AddFunc(removalSpec, func() { gch.RemoveGarbage(TriggerCron) })
 	if err != nil {
 		return err
 	}
@@ -56,34 +85,131 @@ func (gch *GCHandler) DisableCron() {
 	gch.cron = cron.New() // Removes entries
 }
 
-func (gch *GCHandler) IndexGarbage() {
-	gch.mu.RLock()
-	defer gch.mu.RUnlock()
-	currentTime := time.Now()
-	blobs, err := gch.Gc.ListGarbageBlobs()
+func runResult(err error) string {
+	switch {
+	case err == nil:
+		return ResultSuccess
+	case errors.Is(err, ErrAlreadyRunning):
+		return ResultSkipped
+	}
+	return ResultFailure
+}
+
+// observe reports a finished run to the metrics and the run history
+func (gch *GCHandler) observe(run *status.GCRun, startedAt time.Time, err error) {
+	finishedAt := time.Now()
+	run.StartedAt = startedAt.Format(time.RFC3339Nano)
+	run.FinishedAt = finishedAt.Format(time.RFC3339Nano)
+	run.Result = runResult(err)
 	if err != nil {
-		return
+		run.Error = err.Error()
+	}
+	if gch.Metrics != nil {
+		gch.Metrics.ObserveGCRun(run.Kind, run.Result, finishedAt.Sub(startedAt))
 	}
-	_, totalSize, err := gch.FSAnalyzer.GetBlobsSize(blobs)
+	gch.record(run)
+}
+
+// measure maps blobs eligible for deletion to their size; blobs failing to be measured count as empty
+func (gch *GCHandler) measure(eligible []string) map[string]int64 {
+	sizes, _, err := gch.FSAnalyzer.GetBlobsSize(eligible)
 	if err != nil {
-		return
+		log.Printf("[ERROR at GCHandler.measure]: %v", err)
 	}
-	unusedBlobs := len(blobs)
-	statusUpdate := status.Update{
-		UnusedBlobs:    &unusedBlobs,
-		BlobsTotalSize: &totalSize,
-		BlobsIndexedAt: &currentTime,
+	measured := make(map[string]int64, len(eligible))
+	for i, blob := range eligible {
+		measured[blob] = sizes[i]
 	}
-	_ = gch.StatusManager.UpdateStatus(&statusUpdate)
+	return measured
 }
 
-func (gch *GCHandler) RemoveGarbage() {
-	gch.mu.RLock()
-	defer gch.mu.RUnlock()
+// withUntaggedGarbage adds blobs which the removal run frees by deleting untagged manifests first,
+// the registry dry run does not see them as garbage yet
+func (gch *GCHandler) withUntaggedGarbage(blobs []string) ([]string, error) {
+	if gch.Untagged == nil {
+		return blobs, nil
+	}
+	extra, err := gch.Untagged.UntaggedGarbage()
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(blobs))
+	for _, blob := range blobs {
+		known[blob] = struct{}{}
+	}
+	for _, blob := range extra {
+		if _, ok := known[blob]; !ok {
+			blobs = append(blobs, blob)
+		}
+	}
+	return blobs, nil
+}
+
+// index lists garbage blobs, including blobs of untagged manifests, and stores their number and size in the status
+func (gch *GCHandler) index(trigger string, list func() ([]string, error)) ([]string, []int64, error) {
 	currentTime := time.Now()
-	err := gch.Gc.RemoveGarbageBlobs()
+	run := &status.GCRun{Kind: RunIndex, Trigger: trigger}
+	blobs, err := list()
+	if err == nil {
+		blobs, err = gch.withUntaggedGarbage(blobs)
+	}
+	if err == nil {
+		var sizes []int64
+		var totalSize int64
+		sizes, totalSize, err = gch.FSAnalyzer.GetBlobsSize(blobs)
+		if err == nil {
+			unusedBlobs := len(blobs)
+			statusUpdate := status.Update{
+				UnusedBlobs:    &unusedBlobs,
+				BlobsTotalSize: &totalSize,
+				BlobsIndexedAt: &currentTime,
+			}
+			_ = gch.StatusManager.UpdateStatus(&statusUpdate)
+			run.BlobsFound = unusedBlobs
+			run.Bytes = totalSize
+			gch.observe(run, currentTime, nil)
+			return blobs, sizes, nil
+		}
+	}
+	gch.observe(run, currentTime, err)
+	return nil, nil, err
+}
+
+// remove deletes garbage blobs, measured right before the deletion, and resets the status counters
+func (gch *GCHandler) remove(trigger string, remove func(sweep Sweep) ([]string, error)) error {
+	currentTime := time.Now()
+	run := &status.GCRun{Kind: RunRemoval, Trigger: trigger}
+	if gch.Untagged != nil {
+		// Blobs of a half-finished deletion are not collected, the next run completes it
+		deleted, err := gch.Untagged.DeleteUntagged()
+		if deleted > 0 {
+			log.Printf("[INFO at GCHandler.remove]: deleted %d untagged manifests", deleted)
+		}
+		if err != nil {
+			log.Printf("[ERROR at GCHandler.remove]: %v", err)
+			gch.observe(run, currentTime, err)
+			return err
+		}
+	}
+	var sizes map[string]int64
+	removed, err := remove(func(eligible []string) ([]string, error) {
+		sizes = gch.measure(eligible)
+		return gch.FSAnalyzer.RemoveBlobs(eligible)
+	})
+	run.BlobsRemoved = len(removed)
+	for _, blob := range removed {
+		run.Bytes += sizes[blob]
+	}
+	gch.observe(run, currentTime, err)
+	if gch.Metrics != nil {
+		gch.Metrics.AddReclaimedBytes(run.Bytes)
+	}
+	// Summaries persisted for removed manifests would otherwise stay in the storage forever
+	if summariesErr := gch.StatusManager.DeleteCachedSummaries(removed); summariesErr != nil {
+		log.Printf("[ERROR at GCHandler.remove]: %v", summariesErr)
+	}
 	if err != nil {
-		return
+		return err
 	}
 	unusedBlobs := 0
 	totalSize := int64(0)
@@ -93,7 +219,19 @@ func (gch *GCHandler) RemoveGarbage() {
 		BlobsIndexedAt: &currentTime,
 		BlobsCleanedAt: &currentTime,
 	}
-	_ = gch.StatusManager.UpdateStatus(&statusUpdate)
+	return gch.StatusManager.UpdateStatus(&statusUpdate)
+}
+
+func (gch *GCHandler) IndexGarbage(trigger string) {
+	gch.mu.RLock()
+	defer gch.mu.RUnlock()
+	_, _, _ = gch.index(trigger, gch.Gc.ListGarbageBlobs)
+}
+
+func (gch *GCHandler) RemoveGarbage(trigger string) {
+	gch.mu.RLock()
+	defer gch.mu.RUnlock()
+	_ = gch.remove(trigger, gch.Gc.RemoveGarbageBlobs)
 }
 
 func (gch *GCHandler) Cleanup(ctx context.Context) {
@@ -112,8 +250,7 @@ func (gch *GCHandler) Cleanup(ctx context.Context) {
 func (gch *GCHandler) GarbageGetHandler(w http.ResponseWriter, _ *http.Request) {
 	gch.mu.RLock()
 	defer gch.mu.RUnlock()
-	currentTime := time.Now()
-	blobs, err := gch.Gc.TryListGarbageBlobs()
+	blobs, blobSizes, err := gch.index(TriggerAPI, gch.Gc.TryListGarbageBlobs)
 	if err != nil && err == ErrAlreadyRunning {
 		http.Error(w, err.Error(), http.StatusServiceUnavailable)
 		return
@@ -122,40 +259,18 @@ func (gch *GCHandler) GarbageGetHandler(w http.ResponseWriter, _ *http.Request)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	blobSizes, totalSize, err := gch.FSAnalyzer.GetBlobsSize(blobs)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	unusedBlobs := len(blobs)
-	statusUpdate := status.Update{
-		UnusedBlobs:    &unusedBlobs,
-		BlobsTotalSize: &totalSize,
-		BlobsIndexedAt: &currentTime,
-	}
-	err = gch.StatusManager.UpdateStatus(&statusUpdate)
-	garbageInfo := garbage.New()
-	for i, blobDigest := range blobs {
-		garbageInfo.Blobs = append(garbageInfo.Blobs, garbage.GarbageBlob{
-			Size:   blobSizes[i],
-			Digest: blobDigest,
-		})
-	}
-	res, err := json.Marshal(&garbageInfo)
+	garbageInfo, err := Attribute(gch.FSAnalyzer, blobs, blobSizes)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(res)
+	http_response.WriteJson(w, garbageInfo)
 }
 
 func (gch *GCHandler) GarbageDeleteHandler(w http.ResponseWriter, _ *http.Request) {
 	gch.mu.Lock()
 	defer gch.mu.Unlock()
-	currentTime := time.Now()
-	err := gch.Gc.TryRemoveGarbageBlobs()
+	err := gch.remove(TriggerAPI, gch.Gc.TryRemoveGarbageBlobs)
 	if errors.Is(err, ErrAlreadyRunning) {
 		http.Error(w, err.Error(), http.StatusServiceUnavailable)
 		return
@@ -164,18 +279,5 @@ func (gch *GCHandler) GarbageDeleteHandler(w http.ResponseWriter, _ *http.Reques
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	unusedBlobs := 0
-	blobsTotalSize := int64(unusedBlobs)
-	statusUpdate := status.Update{
-		UnusedBlobs:    &unusedBlobs,
-		BlobsTotalSize: &blobsTotalSize,
-		BlobsIndexedAt: &currentTime,
-		BlobsCleanedAt: &currentTime,
-	}
-	err = gch.StatusManager.UpdateStatus(&statusUpdate)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
 	w.WriteHeader(http.StatusOK)
 }
@@ -0,0 +1,105 @@
+package garbage_collector
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/status"
+	"strconv"
+	"time"
+)
+
+// History page sizes
+const (
+	DefaultHistoryPageSize = 100
+	MaxHistoryPageSize     = 1000
+)
+
+// HistoryRetention limits the run history; zero values disable the respective rule
+type HistoryRetention struct {
+	// MaxAge deletes runs recorded earlier
+	MaxAge time.Duration
+	// MaxEntries keeps only the latest runs
+	MaxEntries int
+}
+
+// record appends the run to the history and prunes entries beyond the retention
+func (gch *GCHandler) record(run *status.GCRun) {
+	if err := gch.StatusManager.AddGCRun(run); err != nil {
+		log.Printf("[ERROR at garbage_collector.GCHandler.record]: %v", err)
+		return
+	}
+	var before time.Time
+	if gch.HistoryRetention.MaxAge > 0 {
+		before = time.Now().Add(-gch.HistoryRetention.MaxAge)
+	}
+	if _, err := gch.StatusManager.PruneGCRuns(before, gch.HistoryRetention.MaxEntries); err != nil {
+		log.Printf("[ERROR at garbage_collector.GCHandler.record]: %v", err)
+	}
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// GarbageHistoryHandler lists recorded runs oldest first, "kind", "since" and "until" (RFC 3339, on run start)
+// filter them, "n" and "last" paginate by run id
+func (gch *GCHandler) GarbageHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := status.GCRunFilter{
+		Kind: query.Get("kind"),
+		Last: query.Get("last"),
+		N:    DefaultHistoryPageSize,
+	}
+	if filter.Kind != "" && filter.Kind != RunIndex && filter.Kind != RunRemoval {
+		http.Error(w, "invalid run kind", http.StatusBadRequest)
+		return
+	}
+	if value := query.Get("n"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid page size", http.StatusBadRequest)
+			return
+		}
+		if n > MaxHistoryPageSize {
+			n = MaxHistoryPageSize
+		}
+		filter.N = n
+	}
+	var err error
+	if filter.Since, err = parseTime(query.Get("since")); err != nil {
+		http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.Until, err = parseTime(query.Get("until")); err != nil {
+		http.Error(w, "invalid until: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	n := filter.N
+	// One more run tells whether another page follows
+	filter.N++
+	runs, err := gch.StatusManager.ListGCRuns(filter)
+	if err != nil {
+		log.Printf("[ERROR at garbage_collector.GCHandler.GarbageHistoryHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(runs) > n {
+		runs = runs[:n]
+		next := url.Values{}
+		for _, key := range []string{"kind", "since", "until"} {
+			if value := query.Get(key); value != "" {
+				next.Set(key, value)
+			}
+		}
+		next.Set("n", strconv.Itoa(n))
+		next.Set("last", runs[n-1].ID)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	}
+	http_response.WriteJson(w, &status.GCHistory{Runs: runs})
+}
@@ -0,0 +1,20 @@
+package http_response
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// WriteJson replies 200 with the JSON encoding of v, types generated by easyjson marshal themselves
+func WriteJson(w http.ResponseWriter, v interface{}) {
+	res, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("[ERROR at http_response.WriteJson]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(res)
+}
@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"sort"
+	"time"
+)
+
+//easyjson:json
+type HistoryEntry struct {
+	Created    string `json:"created,omitempty"`
+	CreatedBy  string `json:"createdBy"`
+	Comment    string `json:"comment,omitempty"`
+	EmptyLayer bool   `json:"emptyLayer"`
+	// LayerDigest and Size describe the layer the step produced, they are empty for metadata-only steps
+	LayerDigest string `json:"layerDigest,omitempty"`
+	Size        int64  `json:"size"`
+}
+
+//easyjson:json
+type ConfigView struct {
+	Name          string            `json:"name"`
+	Reference     string            `json:"reference"`
+	ContentDigest string            `json:"dockerContentDigest"`
+	ConfigDigest  string            `json:"configDigest"`
+	MediaType     string            `json:"mediaType"`
+	Architecture  string            `json:"architecture"`
+	OS            string            `json:"os"`
+	Created       string            `json:"created,omitempty"`
+	Author        string            `json:"author,omitempty"`
+	User          string            `json:"user"`
+	WorkingDir    string            `json:"workingDir"`
+	Env           []string          `json:"env"`
+	Entrypoint    []string          `json:"entrypoint"`
+	Cmd           []string          `json:"cmd"`
+	ExposedPorts  []string          `json:"exposedPorts"`
+	Volumes       []string          `json:"volumes"`
+	StopSignal    string            `json:"stopSignal,omitempty"`
+	Labels        map[string]string `json:"labels"`
+	History       []HistoryEntry    `json:"history"`
+}
+
+func setKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// InspectConfig normalizes the image configuration of a single-platform manifest;
+// history steps creating layers are matched with manifest layers in order
+func InspectConfig(name, reference, digest string, m distribution.Manifest, config *v1.Image) ConfigView {
+	view := ConfigView{
+		Name:          name,
+		Reference:     reference,
+		ContentDigest: digest,
+		Architecture:  config.Architecture,
+		OS:            config.OS,
+		Author:        config.Author,
+		User:          config.Config.User,
+		WorkingDir:    config.Config.WorkingDir,
+		Env:           config.Config.Env,
+		Entrypoint:    config.Config.Entrypoint,
+		Cmd:           config.Config.Cmd,
+		ExposedPorts:  setKeys(config.Config.ExposedPorts),
+		Volumes:       setKeys(config.Config.Volumes),
+		StopSignal:    config.Config.StopSignal,
+		Labels:        config.Config.Labels,
+		History:       make([]HistoryEntry, 0, len(config.History)),
+	}
+	view.MediaType, _, _ = m.Payload()
+	if descriptor, ok := ConfigDescriptor(m); ok {
+		view.ConfigDigest = descriptor.Digest.String()
+	}
+	if config.Created != nil {
+		view.Created = config.Created.Format(time.RFC3339Nano)
+	}
+	if view.Env == nil {
+		view.Env = []string{}
+	}
+	if view.Entrypoint == nil {
+		view.Entrypoint = []string{}
+	}
+	if view.Cmd == nil {
+		view.Cmd = []string{}
+	}
+	if view.Labels == nil {
+		view.Labels = map[string]string{}
+	}
+	layers := Layers(m)
+	for _, history := range config.History {
+		entry := HistoryEntry{
+			CreatedBy:  history.CreatedBy,
+			Comment:    history.Comment,
+			EmptyLayer: history.EmptyLayer,
+		}
+		if history.Created != nil {
+			entry.Created = history.Created.Format(time.RFC3339Nano)
+		}
+		if !history.EmptyLayer && len(layers) > 0 {
+			entry.LayerDigest = layers[0].Digest.String()
+			entry.Size = layers[0].Size
+			layers = layers[1:]
+		}
+		view.History = append(view.History, entry)
+	}
+	return view
+}
@@ -0,0 +1,540 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package manifest
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6615c02eDecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Lexer, out *HistoryEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "created":
+			out.Created = string(in.String())
+		case "createdBy":
+			out.CreatedBy = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		case "emptyLayer":
+			out.EmptyLayer = bool(in.Bool())
+		case "layerDigest":
+			out.LayerDigest = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6615c02eEncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.Writer, in HistoryEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"createdBy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CreatedBy))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	{
+		const prefix string = ",\"emptyLayer\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.EmptyLayer))
+	}
+	if in.LayerDigest != "" {
+		const prefix string = ",\"layerDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.LayerDigest))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HistoryEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6615c02eEncodeRegistryCleanerAgentInternalPkgManifest(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HistoryEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6615c02eEncodeRegistryCleanerAgentInternalPkgManifest(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HistoryEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6615c02eDecodeRegistryCleanerAgentInternalPkgManifest(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HistoryEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6615c02eDecodeRegistryCleanerAgentInternalPkgManifest(l, v)
+}
+func easyjson6615c02eDecodeRegistryCleanerAgentInternalPkgManifest1(in *jlexer.Lexer, out *ConfigView) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "reference":
+			out.Reference = string(in.String())
+		case "dockerContentDigest":
+			out.ContentDigest = string(in.String())
+		case "configDigest":
+			out.ConfigDigest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "architecture":
+			out.Architecture = string(in.String())
+		case "os":
+			out.OS = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "workingDir":
+			out.WorkingDir = string(in.String())
+		case "env":
+			if in.IsNull() {
+				in.Skip()
+				out.Env = nil
+			} else {
+				in.Delim('[')
+				if out.Env == nil {
+					if !in.IsDelim(']') {
+						out.Env = make([]string, 0, 4)
+					} else {
+						out.Env = []string{}
+					}
+				} else {
+					out.Env = (out.Env)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Env = append(out.Env, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "entrypoint":
+			if in.IsNull() {
+				in.Skip()
+				out.Entrypoint = nil
+			} else {
+				in.Delim('[')
+				if out.Entrypoint == nil {
+					if !in.IsDelim(']') {
+						out.Entrypoint = make([]string, 0, 4)
+					} else {
+						out.Entrypoint = []string{}
+					}
+				} else {
+					out.Entrypoint = (out.Entrypoint)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.Entrypoint = append(out.Entrypoint, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "cmd":
+			if in.IsNull() {
+				in.Skip()
+				out.Cmd = nil
+			} else {
+				in.Delim('[')
+				if out.Cmd == nil {
+					if !in.IsDelim(']') {
+						out.Cmd = make([]string, 0, 4)
+					} else {
+						out.Cmd = []string{}
+					}
+				} else {
+					out.Cmd = (out.Cmd)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 string
+					v3 = string(in.String())
+					out.Cmd = append(out.Cmd, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "exposedPorts":
+			if in.IsNull() {
+				in.Skip()
+				out.ExposedPorts = nil
+			} else {
+				in.Delim('[')
+				if out.ExposedPorts == nil {
+					if !in.IsDelim(']') {
+						out.ExposedPorts = make([]string, 0, 4)
+					} else {
+						out.ExposedPorts = []string{}
+					}
+				} else {
+					out.ExposedPorts = (out.ExposedPorts)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.ExposedPorts = append(out.ExposedPorts, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "volumes":
+			if in.IsNull() {
+				in.Skip()
+				out.Volumes = nil
+			} else {
+				in.Delim('[')
+				if out.Volumes == nil {
+					if !in.IsDelim(']') {
+						out.Volumes = make([]string, 0, 4)
+					} else {
+						out.Volumes = []string{}
+					}
+				} else {
+					out.Volumes = (out.Volumes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Volumes = append(out.Volumes, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "stopSignal":
+			out.StopSignal = string(in.String())
+		case "labels":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Labels = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v6 string
+					v6 = string(in.String())
+					(out.Labels)[key] = v6
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "history":
+			if in.IsNull() {
+				in.Skip()
+				out.History = nil
+			} else {
+				in.Delim('[')
+				if out.History == nil {
+					if !in.IsDelim(']') {
+						out.History = make([]HistoryEntry, 0, 0)
+					} else {
+						out.History = []HistoryEntry{}
+					}
+				} else {
+					out.History = (out.History)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 HistoryEntry
+					(v7).UnmarshalEasyJSON(in)
+					out.History = append(out.History, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6615c02eEncodeRegistryCleanerAgentInternalPkgManifest1(out *jwriter.Writer, in ConfigView) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"reference\":"
+		out.RawString(prefix)
+		out.String(string(in.Reference))
+	}
+	{
+		const prefix string = ",\"dockerContentDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentDigest))
+	}
+	{
+		const prefix string = ",\"configDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.ConfigDigest))
+	}
+	{
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	{
+		const prefix string = ",\"architecture\":"
+		out.RawString(prefix)
+		out.String(string(in.Architecture))
+	}
+	{
+		const prefix string = ",\"os\":"
+		out.RawString(prefix)
+		out.String(string(in.OS))
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.User))
+	}
+	{
+		const prefix string = ",\"workingDir\":"
+		out.RawString(prefix)
+		out.String(string(in.WorkingDir))
+	}
+	{
+		const prefix string = ",\"env\":"
+		out.RawString(prefix)
+		if in.Env == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Env {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"entrypoint\":"
+		out.RawString(prefix)
+		if in.Entrypoint == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Entrypoint {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v11))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"cmd\":"
+		out.RawString(prefix)
+		if in.Cmd == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Cmd {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v13))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"exposedPorts\":"
+		out.RawString(prefix)
+		if in.ExposedPorts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.ExposedPorts {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"volumes\":"
+		out.RawString(prefix)
+		if in.Volumes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.Volumes {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v17))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.StopSignal != "" {
+		const prefix string = ",\"stopSignal\":"
+		out.RawString(prefix)
+		out.String(string(in.StopSignal))
+	}
+	{
+		const prefix string = ",\"labels\":"
+		out.RawString(prefix)
+		if in.Labels == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v18First := true
+			for v18Name, v18Value := range in.Labels {
+				if v18First {
+					v18First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v18Name))
+				out.RawByte(':')
+				out.String(string(v18Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"history\":"
+		out.RawString(prefix)
+		if in.History == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v19, v20 := range in.History {
+				if v19 > 0 {
+					out.RawByte(',')
+				}
+				(v20).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConfigView) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6615c02eEncodeRegistryCleanerAgentInternalPkgManifest1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConfigView) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6615c02eEncodeRegistryCleanerAgentInternalPkgManifest1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConfigView) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6615c02eDecodeRegistryCleanerAgentInternalPkgManifest1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConfigView) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6615c02eDecodeRegistryCleanerAgentInternalPkgManifest1(l, v)
+}
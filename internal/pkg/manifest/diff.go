@@ -0,0 +1,180 @@
+package manifest
+
+import (
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+//easyjson:json
+type DiffImage struct {
+	Name          string `json:"name"`
+	Reference     string `json:"reference"`
+	ContentDigest string `json:"dockerContentDigest"`
+	Size          int64  `json:"size"`
+	Created       string `json:"created"`
+}
+
+//easyjson:json
+type DiffLayer struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+//easyjson:json
+type KeyChange struct {
+	Key  string `json:"key"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+//easyjson:json
+type KeysDiff struct {
+	Added   []KeyChange `json:"added"`
+	Removed []KeyChange `json:"removed"`
+	Changed []KeyChange `json:"changed"`
+}
+
+//easyjson:json
+type CommandChange struct {
+	From []string `json:"from"`
+	To   []string `json:"to"`
+}
+
+//easyjson:json
+type Diff struct {
+	From DiffImage `json:"from"`
+	To   DiffImage `json:"to"`
+	// SizeDelta is the size of "to" minus the size of "from"
+	SizeDelta     int64       `json:"sizeDelta"`
+	AddedLayers   []DiffLayer `json:"addedLayers"`
+	RemovedLayers []DiffLayer `json:"removedLayers"`
+	SharedLayers  []DiffLayer `json:"sharedLayers"`
+	// BaseLayers counts leading layers both images have in the same order, i.e. the common base image
+	BaseLayers int   `json:"baseLayers"`
+	BaseSize   int64 `json:"baseSize"`
+	// BaseChanged is set when the images do not start with the same layer
+	BaseChanged bool           `json:"baseChanged"`
+	Env         KeysDiff       `json:"env"`
+	Labels      KeysDiff       `json:"labels"`
+	Entrypoint  *CommandChange `json:"entrypoint,omitempty"`
+	Cmd         *CommandChange `json:"cmd,omitempty"`
+	WorkingDir  *KeyChange     `json:"workingDir,omitempty"`
+	User        *KeyChange     `json:"user,omitempty"`
+}
+
+// DiffSide is a single-platform image compared by Compare
+type DiffSide struct {
+	Name      string
+	Reference string
+	Digest    string
+	Manifest  distribution.Manifest
+	Config    *v1.Image
+}
+
+func (side *DiffSide) image() DiffImage {
+	image := DiffImage{Name: side.Name, Reference: side.Reference, ContentDigest: side.Digest}
+	for _, reference := range side.Manifest.References() {
+		image.Size += reference.Size
+	}
+	if side.Config.Created != nil {
+		image.Created = side.Config.Created.Format(time.RFC3339Nano)
+	}
+	return image
+}
+
+// envMap splits KEY=VALUE entries of the image environment
+func envMap(env []string) map[string]string {
+	res := make(map[string]string, len(env))
+	for _, entry := range env {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) == 2 {
+			res[kv[0]] = kv[1]
+		} else {
+			res[kv[0]] = ""
+		}
+	}
+	return res
+}
+
+func diffKeys(from, to map[string]string) KeysDiff {
+	diff := KeysDiff{Added: []KeyChange{}, Removed: []KeyChange{}, Changed: []KeyChange{}}
+	for key, value := range to {
+		old, ok := from[key]
+		if !ok {
+			diff.Added = append(diff.Added, KeyChange{Key: key, To: value})
+		} else if old != value {
+			diff.Changed = append(diff.Changed, KeyChange{Key: key, From: old, To: value})
+		}
+	}
+	for key, value := range from {
+		if _, ok := to[key]; !ok {
+			diff.Removed = append(diff.Removed, KeyChange{Key: key, From: value})
+		}
+	}
+	for _, changes := range [][]KeyChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	}
+	return diff
+}
+
+func diffCommand(from, to []string) *CommandChange {
+	if len(from) == 0 && len(to) == 0 || reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return &CommandChange{From: from, To: to}
+}
+
+func diffValue(key, from, to string) *KeyChange {
+	if from == to {
+		return nil
+	}
+	return &KeyChange{Key: key, From: from, To: to}
+}
+
+// Compare reports what changed from one image to another, layers are matched by digest
+func Compare(from, to *DiffSide) Diff {
+	diff := Diff{
+		From:          from.image(),
+		To:            to.image(),
+		AddedLayers:   []DiffLayer{},
+		RemovedLayers: []DiffLayer{},
+		SharedLayers:  []DiffLayer{},
+		Env:           diffKeys(envMap(from.Config.Config.Env), envMap(to.Config.Config.Env)),
+		Labels:        diffKeys(from.Config.Config.Labels, to.Config.Config.Labels),
+		Entrypoint:    diffCommand(from.Config.Config.Entrypoint, to.Config.Config.Entrypoint),
+		Cmd:           diffCommand(from.Config.Config.Cmd, to.Config.Config.Cmd),
+		WorkingDir:    diffValue("workingDir", from.Config.Config.WorkingDir, to.Config.Config.WorkingDir),
+		User:          diffValue("user", from.Config.Config.User, to.Config.Config.User),
+	}
+	diff.SizeDelta = diff.To.Size - diff.From.Size
+	fromLayers, toLayers := Layers(from.Manifest), Layers(to.Manifest)
+	inFrom := make(map[string]struct{}, len(fromLayers))
+	for _, layer := range fromLayers {
+		inFrom[layer.Digest.String()] = struct{}{}
+	}
+	inTo := make(map[string]struct{}, len(toLayers))
+	for _, layer := range toLayers {
+		inTo[layer.Digest.String()] = struct{}{}
+		if _, ok := inFrom[layer.Digest.String()]; ok {
+			diff.SharedLayers = append(diff.SharedLayers, DiffLayer{Digest: layer.Digest.String(), Size: layer.Size})
+		} else {
+			diff.AddedLayers = append(diff.AddedLayers, DiffLayer{Digest: layer.Digest.String(), Size: layer.Size})
+		}
+	}
+	for _, layer := range fromLayers {
+		if _, ok := inTo[layer.Digest.String()]; !ok {
+			diff.RemovedLayers = append(diff.RemovedLayers, DiffLayer{Digest: layer.Digest.String(), Size: layer.Size})
+		}
+	}
+	for diff.BaseLayers < len(fromLayers) && diff.BaseLayers < len(toLayers) &&
+		fromLayers[diff.BaseLayers].Digest == toLayers[diff.BaseLayers].Digest {
+		diff.BaseSize += fromLayers[diff.BaseLayers].Size
+		diff.BaseLayers++
+	}
+	diff.BaseChanged = len(fromLayers) != 0 && len(toLayers) != 0 && diff.BaseLayers == 0
+	return diff
+}
@@ -0,0 +1,871 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package manifest
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Lexer, out *KeysDiff) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "added":
+			if in.IsNull() {
+				in.Skip()
+				out.Added = nil
+			} else {
+				in.Delim('[')
+				if out.Added == nil {
+					if !in.IsDelim(']') {
+						out.Added = make([]KeyChange, 0, 1)
+					} else {
+						out.Added = []KeyChange{}
+					}
+				} else {
+					out.Added = (out.Added)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 KeyChange
+					(v1).UnmarshalEasyJSON(in)
+					out.Added = append(out.Added, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "removed":
+			if in.IsNull() {
+				in.Skip()
+				out.Removed = nil
+			} else {
+				in.Delim('[')
+				if out.Removed == nil {
+					if !in.IsDelim(']') {
+						out.Removed = make([]KeyChange, 0, 1)
+					} else {
+						out.Removed = []KeyChange{}
+					}
+				} else {
+					out.Removed = (out.Removed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 KeyChange
+					(v2).UnmarshalEasyJSON(in)
+					out.Removed = append(out.Removed, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "changed":
+			if in.IsNull() {
+				in.Skip()
+				out.Changed = nil
+			} else {
+				in.Delim('[')
+				if out.Changed == nil {
+					if !in.IsDelim(']') {
+						out.Changed = make([]KeyChange, 0, 1)
+					} else {
+						out.Changed = []KeyChange{}
+					}
+				} else {
+					out.Changed = (out.Changed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 KeyChange
+					(v3).UnmarshalEasyJSON(in)
+					out.Changed = append(out.Changed, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.Writer, in KeysDiff) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"added\":"
+		out.RawString(prefix[1:])
+		if in.Added == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v4, v5 := range in.Added {
+				if v4 > 0 {
+					out.RawByte(',')
+				}
+				(v5).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"removed\":"
+		out.RawString(prefix)
+		if in.Removed == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v6, v7 := range in.Removed {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				(v7).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"changed\":"
+		out.RawString(prefix)
+		if in.Changed == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Changed {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v KeysDiff) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v KeysDiff) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *KeysDiff) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *KeysDiff) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest(l, v)
+}
+func easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest1(in *jlexer.Lexer, out *KeyChange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "from":
+			out.From = string(in.String())
+		case "to":
+			out.To = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest1(out *jwriter.Writer, in KeyChange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"key\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	if in.From != "" {
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
+	}
+	if in.To != "" {
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(in.To))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v KeyChange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v KeyChange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *KeyChange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *KeyChange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest1(l, v)
+}
+func easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest2(in *jlexer.Lexer, out *DiffLayer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest2(out *jwriter.Writer, in DiffLayer) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffLayer) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DiffLayer) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffLayer) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DiffLayer) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest2(l, v)
+}
+func easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest3(in *jlexer.Lexer, out *DiffImage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "reference":
+			out.Reference = string(in.String())
+		case "dockerContentDigest":
+			out.ContentDigest = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest3(out *jwriter.Writer, in DiffImage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"reference\":"
+		out.RawString(prefix)
+		out.String(string(in.Reference))
+	}
+	{
+		const prefix string = ",\"dockerContentDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentDigest))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffImage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DiffImage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffImage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DiffImage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest3(l, v)
+}
+func easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest4(in *jlexer.Lexer, out *Diff) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			(out.From).UnmarshalEasyJSON(in)
+		case "to":
+			(out.To).UnmarshalEasyJSON(in)
+		case "sizeDelta":
+			out.SizeDelta = int64(in.Int64())
+		case "addedLayers":
+			if in.IsNull() {
+				in.Skip()
+				out.AddedLayers = nil
+			} else {
+				in.Delim('[')
+				if out.AddedLayers == nil {
+					if !in.IsDelim(']') {
+						out.AddedLayers = make([]DiffLayer, 0, 2)
+					} else {
+						out.AddedLayers = []DiffLayer{}
+					}
+				} else {
+					out.AddedLayers = (out.AddedLayers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 DiffLayer
+					(v10).UnmarshalEasyJSON(in)
+					out.AddedLayers = append(out.AddedLayers, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "removedLayers":
+			if in.IsNull() {
+				in.Skip()
+				out.RemovedLayers = nil
+			} else {
+				in.Delim('[')
+				if out.RemovedLayers == nil {
+					if !in.IsDelim(']') {
+						out.RemovedLayers = make([]DiffLayer, 0, 2)
+					} else {
+						out.RemovedLayers = []DiffLayer{}
+					}
+				} else {
+					out.RemovedLayers = (out.RemovedLayers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 DiffLayer
+					(v11).UnmarshalEasyJSON(in)
+					out.RemovedLayers = append(out.RemovedLayers, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sharedLayers":
+			if in.IsNull() {
+				in.Skip()
+				out.SharedLayers = nil
+			} else {
+				in.Delim('[')
+				if out.SharedLayers == nil {
+					if !in.IsDelim(']') {
+						out.SharedLayers = make([]DiffLayer, 0, 2)
+					} else {
+						out.SharedLayers = []DiffLayer{}
+					}
+				} else {
+					out.SharedLayers = (out.SharedLayers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v12 DiffLayer
+					(v12).UnmarshalEasyJSON(in)
+					out.SharedLayers = append(out.SharedLayers, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "baseLayers":
+			out.BaseLayers = int(in.Int())
+		case "baseSize":
+			out.BaseSize = int64(in.Int64())
+		case "baseChanged":
+			out.BaseChanged = bool(in.Bool())
+		case "env":
+			(out.Env).UnmarshalEasyJSON(in)
+		case "labels":
+			(out.Labels).UnmarshalEasyJSON(in)
+		case "entrypoint":
+			if in.IsNull() {
+				in.Skip()
+				out.Entrypoint = nil
+			} else {
+				if out.Entrypoint == nil {
+					out.Entrypoint = new(CommandChange)
+				}
+				(*out.Entrypoint).UnmarshalEasyJSON(in)
+			}
+		case "cmd":
+			if in.IsNull() {
+				in.Skip()
+				out.Cmd = nil
+			} else {
+				if out.Cmd == nil {
+					out.Cmd = new(CommandChange)
+				}
+				(*out.Cmd).UnmarshalEasyJSON(in)
+			}
+		case "workingDir":
+			if in.IsNull() {
+				in.Skip()
+				out.WorkingDir = nil
+			} else {
+				if out.WorkingDir == nil {
+					out.WorkingDir = new(KeyChange)
+				}
+				(*out.WorkingDir).UnmarshalEasyJSON(in)
+			}
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+				out.User = nil
+			} else {
+				if out.User == nil {
+					out.User = new(KeyChange)
+				}
+				(*out.User).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest4(out *jwriter.Writer, in Diff) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix[1:])
+		(in.From).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		(in.To).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"sizeDelta\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SizeDelta))
+	}
+	{
+		const prefix string = ",\"addedLayers\":"
+		out.RawString(prefix)
+		if in.AddedLayers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v13, v14 := range in.AddedLayers {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				(v14).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"removedLayers\":"
+		out.RawString(prefix)
+		if in.RemovedLayers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v15, v16 := range in.RemovedLayers {
+				if v15 > 0 {
+					out.RawByte(',')
+				}
+				(v16).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"sharedLayers\":"
+		out.RawString(prefix)
+		if in.SharedLayers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v17, v18 := range in.SharedLayers {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"baseLayers\":"
+		out.RawString(prefix)
+		out.Int(int(in.BaseLayers))
+	}
+	{
+		const prefix string = ",\"baseSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.BaseSize))
+	}
+	{
+		const prefix string = ",\"baseChanged\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.BaseChanged))
+	}
+	{
+		const prefix string = ",\"env\":"
+		out.RawString(prefix)
+		(in.Env).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"labels\":"
+		out.RawString(prefix)
+		(in.Labels).MarshalEasyJSON(out)
+	}
+	if in.Entrypoint != nil {
+		const prefix string = ",\"entrypoint\":"
+		out.RawString(prefix)
+		(*in.Entrypoint).MarshalEasyJSON(out)
+	}
+	if in.Cmd != nil {
+		const prefix string = ",\"cmd\":"
+		out.RawString(prefix)
+		(*in.Cmd).MarshalEasyJSON(out)
+	}
+	if in.WorkingDir != nil {
+		const prefix string = ",\"workingDir\":"
+		out.RawString(prefix)
+		(*in.WorkingDir).MarshalEasyJSON(out)
+	}
+	if in.User != nil {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		(*in.User).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Diff) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Diff) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Diff) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Diff) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest4(l, v)
+}
+func easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest5(in *jlexer.Lexer, out *CommandChange) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			if in.IsNull() {
+				in.Skip()
+				out.From = nil
+			} else {
+				in.Delim('[')
+				if out.From == nil {
+					if !in.IsDelim(']') {
+						out.From = make([]string, 0, 4)
+					} else {
+						out.From = []string{}
+					}
+				} else {
+					out.From = (out.From)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 string
+					v19 = string(in.String())
+					out.From = append(out.From, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "to":
+			if in.IsNull() {
+				in.Skip()
+				out.To = nil
+			} else {
+				in.Delim('[')
+				if out.To == nil {
+					if !in.IsDelim(']') {
+						out.To = make([]string, 0, 4)
+					} else {
+						out.To = []string{}
+					}
+				} else {
+					out.To = (out.To)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v20 string
+					v20 = string(in.String())
+					out.To = append(out.To, v20)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest5(out *jwriter.Writer, in CommandChange) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix[1:])
+		if in.From == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v21, v22 := range in.From {
+				if v21 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v22))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		if in.To == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.To {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CommandChange) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CommandChange) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson94392e85EncodeRegistryCleanerAgentInternalPkgManifest5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CommandChange) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CommandChange) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson94392e85DecodeRegistryCleanerAgentInternalPkgManifest5(l, v)
+}
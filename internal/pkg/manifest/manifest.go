@@ -1,10 +1,9 @@
 package manifest
 
 import (
-	"encoding/json"
 	"errors"
-	"github.com/docker/distribution/manifest/schema1"
-	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -13,10 +12,16 @@ import (
 var ErrApiStatusCode = errors.New("docker registry API returned error status")
 
 type Result struct {
-	V1Manifest *schema1.Manifest
-	V2Manifest *schema2.Manifest
-	ApiResp    *http.Response
-	Err        error
+	// Manifest holds any negotiated manifest type, see AcceptedMediaTypes
+	Manifest  distribution.Manifest
+	MediaType string
+	ApiResp   *http.Response
+	Err       error
+}
+
+type ConfigResult struct {
+	Config *v1.Image
+	Err    error
 }
 
 func HeadManifest(manifestUrl string, result chan<- Result) {
@@ -26,18 +31,25 @@ func HeadManifest(manifestUrl string, result chan<- Result) {
 		result <- Result{Err: err}
 		return
 	}
-	req.Header.Set("Accept", schema2.MediaTypeManifest)
+	for _, mediaType := range AcceptedMediaTypes {
+		req.Header.Add("Accept", mediaType)
+	}
 	resp, err := client.Do(req)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
 	result <- Result{ApiResp: resp, Err: err}
 }
 
-func getManifestData(manifestUrl string, manifestTypeHeader string) (manifestData []byte, apiResp *http.Response, err error) {
+func getManifestData(manifestUrl string, manifestTypeHeaders ...string) (manifestData []byte, apiResp *http.Response, err error) {
 	client := &http.Client{}
 	req, err := http.NewRequest("GET", manifestUrl, nil)
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Set("Accept", manifestTypeHeader)
+	for _, mediaType := range manifestTypeHeaders {
+		req.Header.Add("Accept", mediaType)
+	}
 
 	apiResp, err = client.Do(req)
 	if err != nil {
@@ -55,26 +67,28 @@ func getManifestData(manifestUrl string, manifestTypeHeader string) (manifestDat
 	return jsonManifestData, apiResp, err
 }
 
-func GetV1Manifest(manifestUrl string, result chan<- Result) {
-	jsonManifestData, apiResp, err := getManifestData(manifestUrl, schema1.MediaTypeManifest)
+// GetManifest negotiates any supported manifest type and parses it according to the returned Content-Type
+func GetManifest(manifestUrl string, result chan<- Result) {
+	manifestData, apiResp, err := getManifestData(manifestUrl, AcceptedMediaTypes...)
 	if err != nil {
 		result <- Result{ApiResp: apiResp, Err: err}
 		return
 	}
-	manifest := &schema1.Manifest{}
-	err = json.Unmarshal(jsonManifestData, manifest)
-	result <- Result{V1Manifest: manifest, ApiResp: apiResp, Err: err}
-	return
+	mediaType := ContentMediaType(apiResp.Header.Get("Content-Type"))
+	if mediaType == "" || mediaType == "application/json" {
+		mediaType = DetectMediaType(manifestData)
+	}
+	m, err := Parse(mediaType, manifestData)
+	result <- Result{Manifest: m, MediaType: mediaType, ApiResp: apiResp, Err: err}
 }
 
-func GetV2Manifest(manifestUrl string, result chan<- Result) {
-	jsonManifestData, apiResp, err := getManifestData(manifestUrl, schema2.MediaTypeManifest)
+// GetImageConfig fetches image configuration blob referenced by a manifest
+func GetImageConfig(blobUrl string, result chan<- ConfigResult) {
+	configData, _, err := getManifestData(blobUrl)
 	if err != nil {
-		result <- Result{ApiResp: apiResp, Err: err}
+		result <- ConfigResult{Err: err}
 		return
 	}
-	manifest := &schema2.Manifest{}
-	err = json.Unmarshal(jsonManifestData, manifest)
-	result <- Result{V2Manifest: manifest, ApiResp: apiResp, Err: err}
-	return
+	config, err := ParseImageConfig(configData)
+	result <- ConfigResult{Config: config, Err: err}
 }
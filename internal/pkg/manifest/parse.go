@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"encoding/json"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/ocischema"
+	"github.com/docker/distribution/manifest/schema2"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"mime"
+)
+
+// AcceptedMediaTypes lists manifest types negotiated with the registry, schema1 is not supported
+var AcceptedMediaTypes = []string{
+	schema2.MediaTypeManifest,
+	manifestlist.MediaTypeManifestList,
+	v1.MediaTypeImageManifest,
+	v1.MediaTypeImageIndex,
+}
+
+// DetectMediaType inspects manifest content read without its Content-Type, e.g. from the registry storage.
+// Other JSON blobs, image configs among them, have no schemaVersion 2 and yield an empty string
+func DetectMediaType(data []byte) string {
+	var versioned struct {
+		SchemaVersion int             `json:"schemaVersion"`
+		MediaType     string          `json:"mediaType"`
+		Config        json.RawMessage `json:"config"`
+		Layers        json.RawMessage `json:"layers"`
+		Manifests     json.RawMessage `json:"manifests"`
+	}
+	if err := json.Unmarshal(data, &versioned); err != nil || versioned.SchemaVersion != 2 {
+		return ""
+	}
+	if versioned.MediaType != "" {
+		return versioned.MediaType
+	}
+	// mediaType is optional in OCI manifests and indexes
+	if versioned.Manifests != nil {
+		return v1.MediaTypeImageIndex
+	}
+	if versioned.Config != nil && versioned.Layers != nil {
+		return v1.MediaTypeImageManifest
+	}
+	return ""
+}
+
+// ContentMediaType strips parameters from the Content-Type header value
+func ContentMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+// Parse decodes the manifest with the schema registered for the media type
+func Parse(mediaType string, data []byte) (distribution.Manifest, error) {
+	m, _, err := distribution.UnmarshalManifest(mediaType, data)
+	return m, err
+}
+
+// IsList reports whether the manifest references other manifests instead of layers
+func IsList(m distribution.Manifest) bool {
+	_, ok := m.(*manifestlist.DeserializedManifestList)
+	return ok
+}
+
+func IsListMediaType(mediaType string) bool {
+	return mediaType == manifestlist.MediaTypeManifestList || mediaType == v1.MediaTypeImageIndex
+}
+
+// ListDescriptors returns child manifests of a manifest list or an OCI index together with their platforms
+func ListDescriptors(m distribution.Manifest) []manifestlist.ManifestDescriptor {
+	if list, ok := m.(*manifestlist.DeserializedManifestList); ok {
+		return list.Manifests
+	}
+	return nil
+}
+
+// ConfigDescriptor returns the image configuration blob of single-platform manifests
+func ConfigDescriptor(m distribution.Manifest) (distribution.Descriptor, bool) {
+	switch m := m.(type) {
+	case *schema2.DeserializedManifest:
+		return m.Config, true
+	case *ocischema.DeserializedManifest:
+		return m.Config, true
+	}
+	return distribution.Descriptor{}, false
+}
+
+func ParseImageConfig(data []byte) (*v1.Image, error) {
+	config := &v1.Image{}
+	err := json.Unmarshal(data, config)
+	return config, err
+}
+
+// Layers returns filesystem layers of single-platform manifests in application order
+func Layers(m distribution.Manifest) []distribution.Descriptor {
+	switch m := m.(type) {
+	case *schema2.DeserializedManifest:
+		return m.Layers
+	case *ocischema.DeserializedManifest:
+		return m.Layers
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package manifest
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Referrer kinds
+const (
+	ReferrerSignature   = "signature"
+	ReferrerAttestation = "attestation"
+	ReferrerSBOM        = "sbom"
+	ReferrerArtifact    = "artifact"
+)
+
+//easyjson:json
+type Referrer struct {
+	Digest       string `json:"digest"`
+	MediaType    string `json:"mediaType,omitempty"`
+	ArtifactType string `json:"artifactType,omitempty"`
+	Kind         string `json:"kind"`
+	// Tag is set for referrers following the cosign "sha256-<hex>.<sig|att|sbom>" tag convention
+	Tag string `json:"tag,omitempty"`
+}
+
+var cosignTagRegexp = regexp.MustCompile(`^([a-z0-9]+)-([a-f0-9]{32,})\.(sig|att|sbom)$`)
+
+var cosignKinds = map[string]string{
+	"sig":  ReferrerSignature,
+	"att":  ReferrerAttestation,
+	"sbom": ReferrerSBOM,
+}
+
+// CosignSubject returns the subject digest and referrer kind encoded in a cosign tag
+func CosignSubject(tag string) (string, string, bool) {
+	match := cosignTagRegexp.FindStringSubmatch(tag)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1] + ":" + match[2], cosignKinds[match[3]], true
+}
+
+// Subject reads the OCI subject of a referrer manifest together with its artifact type;
+// the type falls back to the config media type as the OCI spec prescribes
+func Subject(data []byte) (string, string) {
+	var referrer struct {
+		ArtifactType string `json:"artifactType"`
+		Config       struct {
+			MediaType string `json:"mediaType"`
+		} `json:"config"`
+		Subject *struct {
+			Digest string `json:"digest"`
+		} `json:"subject"`
+	}
+	if json.Unmarshal(data, &referrer) != nil || referrer.Subject == nil {
+		return "", ""
+	}
+	artifactType := referrer.ArtifactType
+	if artifactType == "" {
+		artifactType = referrer.Config.MediaType
+	}
+	return referrer.Subject.Digest, artifactType
+}
+
+// ReferrerKind classifies an artifact type of an OCI referrer
+func ReferrerKind(artifactType string) string {
+	switch {
+	case strings.Contains(artifactType, "sig"):
+		return ReferrerSignature
+	case strings.Contains(artifactType, "in-toto"), strings.Contains(artifactType, "attestation"):
+		return ReferrerAttestation
+	case strings.Contains(artifactType, "spdx"), strings.Contains(artifactType, "cyclonedx"),
+		strings.Contains(artifactType, "sbom"):
+		return ReferrerSBOM
+	}
+	return ReferrerArtifact
+}
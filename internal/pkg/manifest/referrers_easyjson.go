@@ -0,0 +1,113 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package manifest
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonAe8e0e96DecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Lexer, out *Referrer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "artifactType":
+			out.ArtifactType = string(in.String())
+		case "kind":
+			out.Kind = string(in.String())
+		case "tag":
+			out.Tag = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAe8e0e96EncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.Writer, in Referrer) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	if in.MediaType != "" {
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	if in.ArtifactType != "" {
+		const prefix string = ",\"artifactType\":"
+		out.RawString(prefix)
+		out.String(string(in.ArtifactType))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	if in.Tag != "" {
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix)
+		out.String(string(in.Tag))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Referrer) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAe8e0e96EncodeRegistryCleanerAgentInternalPkgManifest(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Referrer) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAe8e0e96EncodeRegistryCleanerAgentInternalPkgManifest(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Referrer) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAe8e0e96DecodeRegistryCleanerAgentInternalPkgManifest(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Referrer) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAe8e0e96DecodeRegistryCleanerAgentInternalPkgManifest(l, v)
+}
@@ -1,11 +1,187 @@
 package manifest
 
+import (
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+//easyjson:json
+type PlatformSummary struct {
+	OS            string `json:"os"`
+	Architecture  string `json:"architecture"`
+	Variant       string `json:"variant,omitempty"`
+	ContentDigest string `json:"dockerContentDigest"`
+	Size          int64  `json:"size"`
+	Created       string `json:"created"`
+}
+
 //easyjson:json
 type Summary struct {
 	Name          string `json:"name"`
 	Tag           string `json:"tag"`
 	Architecture  string `json:"architecture"`
+	OS            string `json:"os"`
 	Created       string `json:"created"`
 	Size          int64  `json:"size"`
 	ContentDigest string `json:"dockerContentDigest"`
+	MediaType     string `json:"mediaType"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Platforms are set for manifest lists and OCI indexes, Size is then the total of unique blobs across them
+	// and Labels merge labels of every platform
+	Platforms []PlatformSummary `json:"platforms,omitempty"`
+
+	// Referrers are signatures, attestations and SBOMs attached to the manifest
+	Referrers []Referrer `json:"referrers,omitempty"`
+
+	// Error is set, instead of the description, for tags of a listing which could not be summarized
+	Error string `json:"error,omitempty"`
+}
+
+//easyjson:json
+type TagSummaries struct {
+	Name string    `json:"name"`
+	Tags []Summary `json:"tags"`
+}
+
+// Summarize builds a summary from the manifest and its image configuration, config may be nil
+func Summarize(name, tag, digest string, m distribution.Manifest, config *v1.Image) Summary {
+	summary := Summary{
+		Name:          name,
+		Tag:           tag,
+		Created:       time.Unix(0, 0).Format(time.RFC3339),
+		ContentDigest: digest,
+	}
+	summary.MediaType, _, _ = m.Payload()
+	for _, reference := range m.References() {
+		summary.Size += reference.Size
+	}
+	if config != nil {
+		summary.Architecture = config.Architecture
+		summary.OS = config.OS
+		summary.Labels = config.Config.Labels
+		if config.Created != nil {
+			summary.Created = config.Created.Format(time.RFC3339Nano)
+		}
+	}
+	return summary
+}
+
+// SummarizeList combines summaries of list children, blobs maps every child blob digest to its size
+func SummarizeList(name, tag, digest string, list distribution.Manifest,
+	children []Summary, blobs map[string]int64) Summary {
+	summary := Summary{
+		Name:          name,
+		Tag:           tag,
+		Created:       time.Unix(0, 0).Format(time.RFC3339),
+		ContentDigest: digest,
+		Platforms:     make([]PlatformSummary, 0, len(children)),
+	}
+	summary.MediaType, _, _ = list.Payload()
+	for _, size := range blobs {
+		summary.Size += size
+	}
+	var architectures, systems []string
+	latest := time.Unix(0, 0)
+	for i, descriptor := range ListDescriptors(list) {
+		if i >= len(children) {
+			break
+		}
+		platform := PlatformSummary{
+			OS:            descriptor.Platform.OS,
+			Architecture:  descriptor.Platform.Architecture,
+			Variant:       descriptor.Platform.Variant,
+			ContentDigest: descriptor.Digest.String(),
+			Size:          children[i].Size,
+			Created:       children[i].Created,
+		}
+		summary.Platforms = append(summary.Platforms, platform)
+		for key, value := range children[i].Labels {
+			if _, ok := summary.Labels[key]; !ok {
+				if summary.Labels == nil {
+					summary.Labels = make(map[string]string)
+				}
+				summary.Labels[key] = value
+			}
+		}
+		architectures = append(architectures, platform.Architecture)
+		systems = append(systems, platform.OS)
+		if created, err := time.Parse(time.RFC3339Nano, platform.Created); err == nil && created.After(latest) {
+			latest = created
+			summary.Created = platform.Created
+		}
+	}
+	summary.Architecture = strings.Join(dedupSorted(architectures), ",")
+	summary.OS = strings.Join(dedupSorted(systems), ",")
+	return summary
+}
+
+func dedupSorted(values []string) []string {
+	sort.Strings(values)
+	res := values[:0]
+	for i, v := range values {
+		if i == 0 || values[i-1] != v {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// SummarizeStored builds the summary from blobs read straight from the storage, manifest lists included
+func SummarizeStored(name, tag, digest string, read func(digest string) ([]byte, error)) (Summary, error) {
+	data, err := read(digest)
+	if err != nil {
+		return Summary{}, err
+	}
+	m, err := Parse(DetectMediaType(data), data)
+	if err != nil {
+		return Summary{}, err
+	}
+	if !IsList(m) {
+		return summarizeStoredImage(name, tag, digest, m, read)
+	}
+	descriptors := ListDescriptors(m)
+	children := make([]Summary, 0, len(descriptors))
+	blobs := make(map[string]int64)
+	for _, descriptor := range descriptors {
+		childDigest := descriptor.Digest.String()
+		childData, err := read(childDigest)
+		if err != nil {
+			return Summary{}, err
+		}
+		child, err := Parse(DetectMediaType(childData), childData)
+		if err != nil {
+			return Summary{}, err
+		}
+		summary, err := summarizeStoredImage(name, "", childDigest, child, read)
+		if err != nil {
+			return Summary{}, err
+		}
+		children = append(children, summary)
+		for _, blob := range child.References() {
+			blobs[blob.Digest.String()] = blob.Size
+		}
+	}
+	return SummarizeList(name, tag, digest, m, children, blobs), nil
+}
+
+func summarizeStoredImage(name, tag, digest string, m distribution.Manifest,
+	read func(digest string) ([]byte, error)) (Summary, error) {
+	descriptor, ok := ConfigDescriptor(m)
+	if !ok {
+		return Summarize(name, tag, digest, m, nil), nil
+	}
+	data, err := read(descriptor.Digest.String())
+	if err != nil {
+		return Summary{}, err
+	}
+	config, err := ParseImageConfig(data)
+	if err != nil {
+		return Summary{}, err
+	}
+	return Summarize(name, tag, digest, m, config), nil
 }
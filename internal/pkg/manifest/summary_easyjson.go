@@ -17,7 +17,112 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Lexer, out *Summary) {
+func easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Lexer, out *TagSummaries) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]Summary, 0, 0)
+					} else {
+						out.Tags = []Summary{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Summary
+					(v1).UnmarshalEasyJSON(in)
+					out.Tags = append(out.Tags, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.Writer, in TagSummaries) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Tags {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TagSummaries) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TagSummaries) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TagSummaries) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TagSummaries) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(l, v)
+}
+func easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest1(in *jlexer.Lexer, out *Summary) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -42,12 +147,84 @@ func easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Le
 			out.Tag = string(in.String())
 		case "architecture":
 			out.Architecture = string(in.String())
+		case "os":
+			out.OS = string(in.String())
 		case "created":
 			out.Created = string(in.String())
 		case "size":
 			out.Size = int64(in.Int64())
 		case "dockerContentDigest":
 			out.ContentDigest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "labels":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Labels = make(map[string]string)
+				} else {
+					out.Labels = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 string
+					v4 = string(in.String())
+					(out.Labels)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "platforms":
+			if in.IsNull() {
+				in.Skip()
+				out.Platforms = nil
+			} else {
+				in.Delim('[')
+				if out.Platforms == nil {
+					if !in.IsDelim(']') {
+						out.Platforms = make([]PlatformSummary, 0, 0)
+					} else {
+						out.Platforms = []PlatformSummary{}
+					}
+				} else {
+					out.Platforms = (out.Platforms)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 PlatformSummary
+					(v5).UnmarshalEasyJSON(in)
+					out.Platforms = append(out.Platforms, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "referrers":
+			if in.IsNull() {
+				in.Skip()
+				out.Referrers = nil
+			} else {
+				in.Delim('[')
+				if out.Referrers == nil {
+					if !in.IsDelim(']') {
+						out.Referrers = make([]Referrer, 0, 0)
+					} else {
+						out.Referrers = []Referrer{}
+					}
+				} else {
+					out.Referrers = (out.Referrers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 Referrer
+					(v6).UnmarshalEasyJSON(in)
+					out.Referrers = append(out.Referrers, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "error":
+			out.Error = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -58,7 +235,7 @@ func easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.Writer, in Summary) {
+func easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest1(out *jwriter.Writer, in Summary) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -77,6 +254,11 @@ func easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.
 		out.RawString(prefix)
 		out.String(string(in.Architecture))
 	}
+	{
+		const prefix string = ",\"os\":"
+		out.RawString(prefix)
+		out.String(string(in.OS))
+	}
 	{
 		const prefix string = ",\"created\":"
 		out.RawString(prefix)
@@ -92,29 +274,187 @@ func easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(out *jwriter.
 		out.RawString(prefix)
 		out.String(string(in.ContentDigest))
 	}
+	{
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	if len(in.Labels) != 0 {
+		const prefix string = ",\"labels\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v7First := true
+			for v7Name, v7Value := range in.Labels {
+				if v7First {
+					v7First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v7Name))
+				out.RawByte(':')
+				out.String(string(v7Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Platforms) != 0 {
+		const prefix string = ",\"platforms\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Platforms {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Referrers) != 0 {
+		const prefix string = ",\"referrers\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v10, v11 := range in.Referrers {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				(v11).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Summary) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(&w, v)
+	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Summary) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest(w, v)
+	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Summary) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(&r, v)
+	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Summary) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest(l, v)
+	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest1(l, v)
+}
+func easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest2(in *jlexer.Lexer, out *PlatformSummary) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "os":
+			out.OS = string(in.String())
+		case "architecture":
+			out.Architecture = string(in.String())
+		case "variant":
+			out.Variant = string(in.String())
+		case "dockerContentDigest":
+			out.ContentDigest = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest2(out *jwriter.Writer, in PlatformSummary) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"os\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.OS))
+	}
+	{
+		const prefix string = ",\"architecture\":"
+		out.RawString(prefix)
+		out.String(string(in.Architecture))
+	}
+	if in.Variant != "" {
+		const prefix string = ",\"variant\":"
+		out.RawString(prefix)
+		out.String(string(in.Variant))
+	}
+	{
+		const prefix string = ",\"dockerContentDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentDigest))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PlatformSummary) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PlatformSummary) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF381ebcaEncodeRegistryCleanerAgentInternalPkgManifest2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PlatformSummary) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PlatformSummary) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF381ebcaDecodeRegistryCleanerAgentInternalPkgManifest2(l, v)
 }
@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/status"
+	"time"
+)
+
+const namespace = "registry_agent"
+
+// ResultSkipped is the result of garbage collector runs refused while another run is in progress
+const ResultSkipped = "skipped"
+
+// Metrics exposes agent and registry state in Prometheus format
+type Metrics struct {
+	StatusManager  *status.Manager
+	registry       *prometheus.Registry
+	startedAt      time.Time
+	gcRuns         *prometheus.CounterVec
+	gcDuration     *prometheus.HistogramVec
+	reclaimedBytes prometheus.Counter
+	proxyRequests  *prometheus.CounterVec
+	proxyDuration  *prometheus.HistogramVec
+}
+
+// InitMetrics registers collectors, ping probes the registry on every scrape
+func InitMetrics(stm *status.Manager, ping func() bool) (*Metrics, error) {
+	if stm == nil || ping == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	m := &Metrics{
+		StatusManager: stm,
+		registry:      prometheus.NewRegistry(),
+		startedAt:     time.Now(),
+		gcRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gc_runs_total",
+			Help:      "Garbage index and removal runs by result.",
+		}, []string{"kind", "result"}),
+		gcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "gc_run_duration_seconds",
+			Help:      "Duration of garbage index and removal runs.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"kind"}),
+		reclaimedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gc_reclaimed_bytes_total",
+			Help:      "Bytes of blobs removed by the garbage collector, measured right before the removal run deletes them.",
+		}),
+		proxyRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "proxy_requests_total",
+			Help:      "Registry API requests passed through the agent.",
+		}, []string{"method", "code"}),
+		proxyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "proxy_request_duration_seconds",
+			Help:      "Latency of registry API requests passed through the agent.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+	gauges := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "registry_up",
+			Help:      "Whether the registry API answers, probed on scrape.",
+		}, func() float64 {
+			if ping() {
+				return 1
+			}
+			return 0
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "unused_blobs",
+			Help:      "Garbage blobs found by the last index run.",
+		}, func() float64 {
+			return float64(m.StatusManager.Snapshot().UnusedBlobs)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "unused_blobs_bytes",
+			Help:      "Size of garbage blobs found by the last index run.",
+		}, func() float64 {
+			return float64(m.StatusManager.Snapshot().BlobsTotalSize)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_cleanup_timestamp_seconds",
+			Help:      "Time of the last successful garbage removal, 0 if there was none.",
+		}, func() float64 {
+			cleanedAt, ok := m.lastCleanup()
+			if !ok {
+				return 0
+			}
+			return float64(cleanedAt.Unix())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "seconds_since_last_cleanup",
+			Help:      "Seconds since the last successful garbage removal, counted from agent start if there was none.",
+		}, func() float64 {
+			cleanedAt, ok := m.lastCleanup()
+			if !ok {
+				cleanedAt = m.startedAt
+			}
+			return time.Since(cleanedAt).Seconds()
+		}),
+	}
+	collectorList := append(gauges,
+		m.gcRuns, m.gcDuration, m.reclaimedBytes, m.proxyRequests, m.proxyDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	for _, collector := range collectorList {
+		if err := m.registry.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// lastCleanup reports false while the status holds the zero time of a registry never cleaned
+func (m *Metrics) lastCleanup() (time.Time, bool) {
+	cleanedAt, err := time.Parse(time.RFC3339, m.StatusManager.Snapshot().BlobsCleanedAt)
+	return cleanedAt, err == nil && cleanedAt.Unix() > 0
+}
+
+// ObserveGCRun counts a finished garbage collector run, runs skipped as another one was in progress are not timed
+func (m *Metrics) ObserveGCRun(kind, result string, duration time.Duration) {
+	m.gcRuns.WithLabelValues(kind, result).Inc()
+	if result != ResultSkipped {
+		m.gcDuration.WithLabelValues(kind).Observe(duration.Seconds())
+	}
+}
+
+func (m *Metrics) AddReclaimedBytes(bytes int64) {
+	m.reclaimedBytes.Add(float64(bytes))
+}
+
+// InstrumentProxy counts and times requests by method and response status
+func (m *Metrics) InstrumentProxy(next http.Handler) http.Handler {
+	return promhttp.InstrumentHandlerDuration(m.proxyDuration,
+		promhttp.InstrumentHandlerCounter(m.proxyRequests, next))
+}
+
+// Handler serves the metrics in Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
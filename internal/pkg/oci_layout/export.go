@@ -0,0 +1,145 @@
+package oci_layout
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"github.com/docker/distribution"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"io"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"time"
+)
+
+// dockerManifest is an entry of manifest.json written by docker save
+type dockerManifest struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+	Layers   []string `json:"Layers"`
+}
+
+// resolve fetches the manifest with children, nothing is written before the whole image is known
+func (h *Handler) resolve(repo, reference string) (*image, error) {
+	root, err := h.Client.GetManifest(repo, reference)
+	if err != nil {
+		return nil, err
+	}
+	if root.Digest == "" {
+		root.Digest = digest.FromBytes(root.Data).String()
+	}
+	return collect(root, func(digest string) (*registry_client.RawManifest, error) {
+		return h.Client.GetManifest(repo, digest)
+	})
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  time.Unix(0, 0),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+func writeJsonFile(tw *tar.Writer, name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeFile(tw, name, data)
+}
+
+func writeManifest(tw *tar.Writer, m *registry_client.RawManifest) error {
+	return writeFile(tw, blobPath(digest.Digest(m.Digest)), m.Data)
+}
+
+// streamBlob copies the blob from the registry verifying its size and digest
+func (h *Handler) streamBlob(tw *tar.Writer, repo string, blob distribution.Descriptor) error {
+	content, _, err := h.Client.GetBlob(repo, blob.Digest.String())
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     blobPath(blob.Digest),
+		Mode:     0644,
+		Size:     blob.Size,
+		ModTime:  time.Unix(0, 0),
+	})
+	if err != nil {
+		return err
+	}
+	verifier := blob.Digest.Verifier()
+	n, err := io.Copy(tw, io.TeeReader(io.LimitReader(content, blob.Size), verifier))
+	if err != nil {
+		return err
+	}
+	if n != blob.Size || !verifier.Verified() {
+		return fmt.Errorf("blob %s does not match its descriptor", blob.Digest)
+	}
+	return nil
+}
+
+// Export writes the image as an OCI image layout tar; single images also get docker save manifest.json
+func (h *Handler) Export(w io.Writer, repo, reference string, img *image) error {
+	tw := tar.NewWriter(w)
+	descriptor := v1.Descriptor{
+		MediaType: img.manifest.MediaType,
+		Digest:    digest.Digest(img.manifest.Digest),
+		Size:      int64(len(img.manifest.Data)),
+	}
+	var repoTags []string
+	if _, err := digest.Parse(reference); err != nil {
+		descriptor.Annotations = map[string]string{
+			v1.AnnotationRefName: reference,
+			AnnotationImageName:  repo + ":" + reference,
+		}
+		repoTags = []string{repo + ":" + reference}
+	}
+	err := writeJsonFile(tw, v1.ImageLayoutFile, &v1.ImageLayout{Version: v1.ImageLayoutVersion})
+	if err == nil {
+		err = writeJsonFile(tw, IndexFile, &v1.Index{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			Manifests: []v1.Descriptor{descriptor},
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if len(img.children) == 0 {
+		m, err := manifest.Parse(img.manifest.MediaType, img.manifest.Data)
+		if err != nil {
+			return err
+		}
+		if config, ok := manifest.ConfigDescriptor(m); ok {
+			entry := dockerManifest{Config: blobPath(config.Digest), RepoTags: repoTags, Layers: []string{}}
+			for _, layer := range manifest.Layers(m) {
+				entry.Layers = append(entry.Layers, blobPath(layer.Digest))
+			}
+			if err = writeJsonFile(tw, DockerManifestFile, []dockerManifest{entry}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range append([]*registry_client.RawManifest{img.manifest}, img.children...) {
+		if err = writeManifest(tw, m); err != nil {
+			return err
+		}
+	}
+	for _, blob := range img.blobs {
+		if err = h.streamBlob(tw, repo, blob); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
@@ -0,0 +1,311 @@
+package oci_layout
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/quota"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxIndexSize limits index.json read from an archive
+	MaxIndexSize = 4 << 20
+	// DefaultMaxImportSize limits uploaded archives unless import_max_size is configured
+	DefaultMaxImportSize = 10 << 30
+)
+
+var (
+	ErrInvalidTag      = errors.New("invalid tag")
+	ErrMissingTag      = errors.New("manifest has no tag, pass the tag parameter")
+	ErrArchiveTooLarge = errors.New("archive exceeds the import size limit")
+
+	anchoredTagRegexp = regexp.MustCompile("^" + reference.TagRegexp.String() + "$")
+)
+
+//easyjson:json
+type ImportedImage struct {
+	Tag       string `json:"tag"`
+	Digest    string `json:"digest"`
+	MediaType string `json:"mediaType"`
+	// Manifests counts child manifests of a list
+	Manifests int `json:"manifests"`
+}
+
+//easyjson:json
+type ImportReport struct {
+	Name          string          `json:"name"`
+	Images        []ImportedImage `json:"images"`
+	UploadedBlobs int             `json:"uploadedBlobs"`
+	ExistingBlobs int             `json:"existingBlobs"`
+}
+
+// extractFile writes a blob of the archive verifying the digest given by its name
+func extractFile(dir, name string, content io.Reader) error {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 {
+		return nil
+	}
+	d := digest.NewDigestFromEncoded(digest.Algorithm(parts[1]), parts[2])
+	if err := d.Validate(); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+	}
+	target := filepath.Join(dir, filepath.FromSlash(blobPath(d)))
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	verifier := d.Verifier()
+	if _, err = io.Copy(f, io.TeeReader(content, verifier)); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("%w: %s does not match its digest", ErrInvalidArchive, name)
+	}
+	return nil
+}
+
+// extract unpacks index.json and blobs of a plain or gzip compressed archive, other files are skipped.
+// Unpacked blobs may take up to maxSize bytes whatever the compression, and no more than the limit of the
+// quota q when given; blobs exceeding either are rejected before they are written
+func extract(archive io.Reader, dir string, maxSize int64, q *quota.Quota) error {
+	buffered := bufio.NewReader(archive)
+	var r io.Reader = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	blobs := make(map[string]struct{})
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if errors.Is(err, ErrArchiveTooLarge) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		switch {
+		case name == IndexFile:
+			data, err := ioutil.ReadAll(io.LimitReader(tr, MaxIndexSize))
+			if err == nil {
+				err = ioutil.WriteFile(filepath.Join(dir, IndexFile), data, 0600)
+			}
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(name, "blobs/"):
+			if _, ok := blobs[name]; !ok {
+				blobs[name] = struct{}{}
+				total += hdr.Size
+			}
+			if maxSize > 0 && total > maxSize {
+				return fmt.Errorf("%w: unpacked blobs take more than %d bytes", ErrArchiveTooLarge, maxSize)
+			}
+			if q != nil && total > q.Limit {
+				return fmt.Errorf("%w: quota %s: archive blobs take more than %d bytes", quota.ErrExceeded, q.Prefix, q.Limit)
+			}
+			if err = extractFile(dir, name, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// tagOf takes the tag from the parameter or from the reference name annotations of the descriptor
+func tagOf(descriptor v1.Descriptor, tag string) string {
+	if tag != "" {
+		return tag
+	}
+	for _, key := range []string{v1.AnnotationRefName, AnnotationImageName} {
+		name := descriptor.Annotations[key]
+		if anchoredTagRegexp.MatchString(name) {
+			return name
+		}
+		if parsed, err := reference.Parse(name); err == nil {
+			if tagged, ok := parsed.(reference.Tagged); ok {
+				return tagged.Tag()
+			}
+		}
+	}
+	return ""
+}
+
+// Import pushes images listed in index.json of the archive to the repository; tag overrides reference
+// names of the archive and requires a single image in it
+func (h *Handler) Import(repo, tag string, archive io.Reader) (*ImportReport, error) {
+	if tag != "" && !anchoredTagRegexp.MatchString(tag) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+	}
+	dir, err := ioutil.TempDir("", "oci-import-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err = extract(archive, dir, h.MaxImportSize, h.Quota.Match(repo)); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, IndexFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s not found", ErrInvalidArchive, IndexFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := v1.Index{}
+	if err = json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, IndexFile, err)
+	}
+	if len(index.Manifests) == 0 || tag != "" && len(index.Manifests) != 1 {
+		return nil, fmt.Errorf("%w: %s lists %d manifests", ErrInvalidArchive, IndexFile, len(index.Manifests))
+	}
+	read := func(value string) (*registry_client.RawManifest, error) {
+		d, err := digest.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(blobPath(d))))
+		if err != nil {
+			return nil, fmt.Errorf("%w: manifest %s: %v", ErrInvalidArchive, value, err)
+		}
+		return &registry_client.RawManifest{Data: data, MediaType: manifest.DetectMediaType(data), Digest: value}, nil
+	}
+	report := &ImportReport{Name: repo, Images: make([]ImportedImage, 0, len(index.Manifests))}
+	images := make([]*image, 0, len(index.Manifests))
+	blobs := make(map[string]int64)
+	for _, descriptor := range index.Manifests {
+		imageTag := tagOf(descriptor, tag)
+		if imageTag == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingTag, descriptor.Digest)
+		}
+		root, err := read(descriptor.Digest.String())
+		if err != nil {
+			return nil, err
+		}
+		if descriptor.MediaType != "" {
+			root.MediaType = descriptor.MediaType
+		}
+		img, err := collect(root, read)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		images = append(images, img)
+		for _, blob := range img.blobs {
+			blobs[blob.Digest.String()] = blob.Size
+		}
+		report.Images = append(report.Images, ImportedImage{
+			Tag:       imageTag,
+			Digest:    root.Digest,
+			MediaType: root.MediaType,
+			Manifests: len(img.children),
+		})
+	}
+	release, err := h.Quota.Admit(repo, blobs)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	uploads := make([]string, 0, len(blobs))
+	for blob := range blobs {
+		uploads = append(uploads, blob)
+	}
+	sort.Strings(uploads)
+	for _, blob := range uploads {
+		if err = h.uploadBlob(dir, repo, blob, report); err != nil {
+			return nil, err
+		}
+	}
+	for i, img := range images {
+		for _, child := range img.children {
+			if _, err = h.Client.PutManifest(repo, child.Digest, child); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = h.Client.PutManifest(repo, report.Images[i].Tag, img.manifest); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// limitedBody tells the body limit of http.MaxBytesReader apart from other read errors, the limit error
+// is not exported; the reader fails only once limit bytes have been read
+type limitedBody struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if err != nil && err != io.EOF && b.read >= b.limit {
+		err = ErrArchiveTooLarge
+	}
+	return n, err
+}
+
+// uploadBlob pushes the extracted blob unless the repository has it already; blobs missing in the archive
+// are accepted when the repository has them
+func (h *Handler) uploadBlob(dir, repo, blob string, report *ImportReport) error {
+	d, err := digest.Parse(blob)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	exists, err := h.Client.BlobExists(repo, blob)
+	if err != nil {
+		return err
+	}
+	if exists {
+		report.ExistingBlobs++
+		return nil
+	}
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(blobPath(d))))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: blob %s not found", ErrInvalidArchive, d)
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err = h.Client.UploadBlob(repo, blob, info.Size(), f); err != nil {
+		return err
+	}
+	report.UploadedBlobs++
+	return nil
+}
@@ -0,0 +1,225 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package oci_layout
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson63a4a5efDecodeRegistryCleanerAgentInternalPkgOciLayout(in *jlexer.Lexer, out *ImportedImage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tag":
+			out.Tag = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "manifests":
+			out.Manifests = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeRegistryCleanerAgentInternalPkgOciLayout(out *jwriter.Writer, in ImportedImage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Tag))
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	{
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		out.Int(int(in.Manifests))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportedImage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeRegistryCleanerAgentInternalPkgOciLayout(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportedImage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeRegistryCleanerAgentInternalPkgOciLayout(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportedImage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeRegistryCleanerAgentInternalPkgOciLayout(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportedImage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeRegistryCleanerAgentInternalPkgOciLayout(l, v)
+}
+func easyjson63a4a5efDecodeRegistryCleanerAgentInternalPkgOciLayout1(in *jlexer.Lexer, out *ImportReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "images":
+			if in.IsNull() {
+				in.Skip()
+				out.Images = nil
+			} else {
+				in.Delim('[')
+				if out.Images == nil {
+					if !in.IsDelim(']') {
+						out.Images = make([]ImportedImage, 0, 1)
+					} else {
+						out.Images = []ImportedImage{}
+					}
+				} else {
+					out.Images = (out.Images)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ImportedImage
+					(v1).UnmarshalEasyJSON(in)
+					out.Images = append(out.Images, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "uploadedBlobs":
+			out.UploadedBlobs = int(in.Int())
+		case "existingBlobs":
+			out.ExistingBlobs = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeRegistryCleanerAgentInternalPkgOciLayout1(out *jwriter.Writer, in ImportReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"images\":"
+		out.RawString(prefix)
+		if in.Images == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Images {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"uploadedBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.UploadedBlobs))
+	}
+	{
+		const prefix string = ",\"existingBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.ExistingBlobs))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeRegistryCleanerAgentInternalPkgOciLayout1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeRegistryCleanerAgentInternalPkgOciLayout1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeRegistryCleanerAgentInternalPkgOciLayout1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeRegistryCleanerAgentInternalPkgOciLayout1(l, v)
+}
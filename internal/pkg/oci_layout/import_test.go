@@ -0,0 +1,133 @@
+package oci_layout
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/gorilla/mux"
+	"github.com/opencontainers/go-digest"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/quota"
+	"strings"
+	"testing"
+)
+
+type archiveFile struct {
+	name    string
+	content string
+}
+
+// blobFile names the content after its digest as the OCI image layout does
+func blobFile(content string) archiveFile {
+	return archiveFile{"blobs/sha256/" + digest.FromString(content).Encoded(), content}
+}
+
+func buildArchive(t *testing.T, compressed bool, files ...archiveFile) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	var w io.Writer = buf
+	var gz *gzip.Writer
+	if compressed {
+		gz = gzip.NewWriter(buf)
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Mode: 0600, Size: int64(len(f.content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestExtract(t *testing.T) {
+	small := blobFile(strings.Repeat("a", 40))
+	large := blobFile(strings.Repeat("b", 80))
+	tests := []struct {
+		name       string
+		compressed bool
+		files      []archiveFile
+		maxSize    int64
+		quota      *quota.Quota
+		wantErr    error
+	}{
+		{"within limits", false, []archiveFile{{IndexFile, "{}"}, small, large}, 120,
+			&quota.Quota{Prefix: "team", Limit: 120}, nil},
+		{"no limits", true, []archiveFile{small, large}, 0, nil, nil},
+		{"index is not counted", false, []archiveFile{{IndexFile, strings.Repeat(" ", 100)}, small}, 40, nil, nil},
+		{"duplicate entries count once", false, []archiveFile{small, small, small}, 40, nil, nil},
+		{"over the size limit", false, []archiveFile{small, large}, 119, nil, ErrArchiveTooLarge},
+		{"compressed over the size limit", true, []archiveFile{small, large}, 119, nil, ErrArchiveTooLarge},
+		{"over the quota", false, []archiveFile{small, large}, 0,
+			&quota.Quota{Prefix: "team", Limit: 100}, quota.ErrExceeded},
+		{"digest mismatch", false, []archiveFile{{small.name, strings.Repeat("c", 40)}}, 0, nil,
+			ErrInvalidArchive},
+		{"invalid digest", false, []archiveFile{{"blobs/sha256/abc", "abc"}}, 0, nil, ErrInvalidArchive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := buildArchive(t, tt.compressed, tt.files...)
+			err := extract(bytes.NewReader(archive), t.TempDir(), tt.maxSize, tt.quota)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportHandlerLimits(t *testing.T) {
+	qh, err := quota.InitQuotaHandler(fs_analyzer.NewFSAnalyzer(t.TempDir()),
+		[]quota.Rule{{Prefix: "team", Limit: "1000"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{Quota: qh, MaxImportSize: 10000}
+	bomb := buildArchive(t, true, blobFile(strings.Repeat("0", 20000)))
+	tests := []struct {
+		name   string
+		repo   string
+		body   []byte
+		length int64
+		want   int
+	}{
+		{"declared length over the limit", "other", make([]byte, 10), 10001, http.StatusRequestEntityTooLarge},
+		{"body over the limit", "other", buildArchive(t, false, archiveFile{"skipped", strings.Repeat("0", 10001)}), -1,
+			http.StatusRequestEntityTooLarge},
+		{"unpacked over the limit", "other", bomb, int64(len(bomb)), http.StatusRequestEntityTooLarge},
+		{"unpacked over the quota", "team/api", buildArchive(t, true, blobFile(strings.Repeat("0", 1001))), -1,
+			http.StatusForbidden},
+		{"not an archive", "other", []byte("not a tar"), -1, http.StatusBadRequest},
+	}
+	if len(bomb) >= 10000 {
+		t.Fatalf("compressed archive takes %d bytes", len(bomb))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", ioutil.NopCloser(bytes.NewReader(tt.body)))
+			r.ContentLength = tt.length
+			r = mux.SetURLVars(r, map[string]string{"repo": tt.repo})
+			w := httptest.NewRecorder()
+			h.ImportHandler(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
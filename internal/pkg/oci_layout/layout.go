@@ -0,0 +1,72 @@
+package oci_layout
+
+import (
+	"errors"
+	"github.com/docker/distribution"
+	"github.com/opencontainers/go-digest"
+	"path"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+)
+
+const (
+	IndexFile = "index.json"
+	// DockerManifestFile makes single image archives loadable by docker versions without OCI layout support
+	DockerManifestFile = "manifest.json"
+	// AnnotationImageName carries the full image name, containerd and docker load name images by it
+	AnnotationImageName = "io.containerd.image.name"
+)
+
+var ErrInvalidArchive = errors.New("invalid OCI image layout archive")
+
+// image is a manifest together with child manifests and blobs it references
+type image struct {
+	manifest *registry_client.RawManifest
+	children []*registry_client.RawManifest
+	// blobs are configs and layers of every image, deduplicated, in manifest order
+	blobs []distribution.Descriptor
+}
+
+// blobPath is the location of the blob inside the layout
+func blobPath(d digest.Digest) string {
+	return path.Join("blobs", d.Algorithm().String(), d.Encoded())
+}
+
+// collect parses the manifest and reads children of manifest lists
+func collect(root *registry_client.RawManifest, read func(digest string) (*registry_client.RawManifest, error)) (*image, error) {
+	m, err := manifest.Parse(root.MediaType, root.Data)
+	if err != nil {
+		return nil, err
+	}
+	img := &image{manifest: root}
+	images := []distribution.Manifest{m}
+	if manifest.IsList(m) {
+		images = images[:0]
+		for _, descriptor := range manifest.ListDescriptors(m) {
+			child, err := read(descriptor.Digest.String())
+			if err != nil {
+				return nil, err
+			}
+			if child.Digest == "" {
+				child.Digest = descriptor.Digest.String()
+			}
+			parsed, err := manifest.Parse(child.MediaType, child.Data)
+			if err != nil {
+				return nil, err
+			}
+			img.children = append(img.children, child)
+			images = append(images, parsed)
+		}
+	}
+	seen := make(map[digest.Digest]struct{})
+	for _, m := range images {
+		for _, blob := range m.References() {
+			if _, ok := seen[blob.Digest]; ok {
+				continue
+			}
+			seen[blob.Digest] = struct{}{}
+			img.blobs = append(img.blobs, blob)
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,88 @@
+package oci_layout
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/quota"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"strings"
+)
+
+type Handler struct {
+	Client *registry_client.Client
+	Quota  *quota.Handler
+	// MaxImportSize limits the request body of imports and the size of blobs unpacked from it
+	MaxImportSize int64
+}
+
+func InitLayoutHandler(client *registry_client.Client, qh *quota.Handler) (*Handler, error) {
+	if client == nil || qh == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		Client:        client,
+		Quota:         qh,
+		MaxImportSize: DefaultMaxImportSize,
+	}, nil
+}
+
+// archiveName builds the file name offered for download, e.g. team_api_1.0.tar
+func archiveName(repo, reference string) string {
+	return strings.NewReplacer("/", "_", ":", "-").Replace(repo+"_"+reference) + ".tar"
+}
+
+// ExportHandler streams the image, manifest lists with every platform, as an OCI image layout tar
+func (h *Handler) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	img, err := h.resolve(vars["repo"], vars["tag"])
+	switch {
+	case errors.Is(err, registry_client.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, registry_client.ErrDenied):
+		agent_errors.WriteRegistryError(w, http.StatusForbidden, agent_errors.CodeDenied, err.Error(), nil)
+		return
+	case err != nil:
+		log.Printf("[ERROR at oci_layout.Handler.ExportHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, archiveName(vars["repo"], vars["tag"])))
+	w.Header().Set(registry_client.HeaderContentDigest, img.manifest.Digest)
+	if err = h.Export(w, vars["repo"], vars["tag"], img); err != nil {
+		// The status is sent already, the client is left with a truncated archive
+		log.Printf("[ERROR at oci_layout.Handler.ExportHandler]: %v", err)
+	}
+}
+
+// ImportHandler pushes images of an OCI image layout tar, optionally gzip compressed, to the repository
+func (h *Handler) ImportHandler(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+	if r.ContentLength > h.MaxImportSize {
+		http.Error(w, ErrArchiveTooLarge.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	body := &limitedBody{r: http.MaxBytesReader(w, r.Body, h.MaxImportSize), limit: h.MaxImportSize}
+	report, err := h.Import(repo, r.URL.Query().Get("tag"), body)
+	switch {
+	case errors.Is(err, ErrArchiveTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrInvalidArchive), errors.Is(err, ErrInvalidTag), errors.Is(err, ErrMissingTag):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, quota.ErrExceeded), errors.Is(err, registry_client.ErrDenied):
+		agent_errors.WriteRegistryError(w, http.StatusForbidden, agent_errors.CodeDenied, err.Error(), nil)
+	case err != nil:
+		log.Printf("[ERROR at oci_layout.Handler.ImportHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		log.Printf("[INFO at oci_layout.Handler.ImportHandler]: imported %d images to %s, %d blobs uploaded",
+			len(report.Images), repo, report.UploadedBlobs)
+		http_response.WriteJson(w, report)
+	}
+}
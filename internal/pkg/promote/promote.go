@@ -0,0 +1,283 @@
+package promote
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"log"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"sort"
+	"sync"
+)
+
+// Promotion results
+const (
+	ResultPromoted  = "promoted"
+	ResultUnchanged = "unchanged"
+	ResultFailed    = "failed"
+)
+
+const ActionPromote = "promote"
+
+var (
+	ErrInvalidSource = errors.New("source must be <name>:<tag> or <name>@<digest>")
+	ErrInvalidTarget = errors.New("target must be <name>:<tag>")
+	ErrTargetExists  = errors.New("target tag points to another manifest")
+)
+
+//easyjson:json
+type Request struct {
+	// Source is given as "<name>:<tag>" or "<name>@<digest>"
+	Source string `json:"source"`
+	// Target is given as "<name>:<tag>"
+	Target string `json:"target"`
+	// Overwrite allows moving an existing target tag to the promoted manifest
+	Overwrite bool `json:"overwrite"`
+}
+
+//easyjson:json
+type Report struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Digest    string `json:"digest"`
+	MediaType string `json:"mediaType"`
+	Result    string `json:"result"`
+	// PreviousDigest is the manifest the target tag pointed to before
+	PreviousDigest string `json:"previousDigest,omitempty"`
+	// Manifests counts child manifests of a list pushed to the target repository
+	Manifests     int `json:"manifests"`
+	MountedBlobs  int `json:"mountedBlobs"`
+	CopiedBlobs   int `json:"copiedBlobs"`
+	ExistingBlobs int `json:"existingBlobs"`
+}
+
+func parseSource(ref string) (string, string, error) {
+	parsed, err := reference.Parse(ref)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidSource, err)
+	}
+	named, ok := parsed.(reference.Named)
+	if !ok {
+		return "", "", ErrInvalidSource
+	}
+	if digested, ok := parsed.(reference.Digested); ok {
+		return named.Name(), digested.Digest().String(), nil
+	}
+	if tagged, ok := parsed.(reference.Tagged); ok {
+		return named.Name(), tagged.Tag(), nil
+	}
+	return "", "", ErrInvalidSource
+}
+
+func parseTarget(ref string) (string, string, error) {
+	parsed, err := reference.Parse(ref)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidTarget, err)
+	}
+	named, ok := parsed.(reference.Named)
+	if !ok {
+		return "", "", ErrInvalidTarget
+	}
+	tagged, ok := parsed.(reference.Tagged)
+	if _, digested := parsed.(reference.Digested); !ok || digested {
+		return "", "", ErrInvalidTarget
+	}
+	return named.Name(), tagged.Tag(), nil
+}
+
+// content lists what the target repository needs before the manifest can be pushed there
+type content struct {
+	children []*registry_client.RawManifest
+	blobs    map[string]int64
+}
+
+func (ph *Handler) collect(repo string, src *registry_client.RawManifest) (*content, error) {
+	m, err := manifest.Parse(src.MediaType, src.Data)
+	if err != nil {
+		return nil, err
+	}
+	c := &content{blobs: make(map[string]int64)}
+	images := []distribution.Manifest{m}
+	if manifest.IsList(m) {
+		images = images[:0]
+		for _, descriptor := range manifest.ListDescriptors(m) {
+			child, err := ph.Client.GetManifest(repo, descriptor.Digest.String())
+			if err != nil {
+				return nil, err
+			}
+			if child.Digest == "" {
+				child.Digest = descriptor.Digest.String()
+			}
+			parsed, err := manifest.Parse(child.MediaType, child.Data)
+			if err != nil {
+				return nil, err
+			}
+			c.children = append(c.children, child)
+			images = append(images, parsed)
+		}
+	}
+	for _, image := range images {
+		for _, blob := range image.References() {
+			c.blobs[blob.Digest.String()] = blob.Size
+		}
+	}
+	return c, nil
+}
+
+// transfer links blobs and pushes child manifests to the target repository by digest; neither is visible
+// through tags, so a failed promotion leaves the target as it was. Pushed child manifests are returned
+// to be removed when the promotion fails later.
+func (ph *Handler) transfer(from, to string, c *content, report *Report) ([]string, error) {
+	blobs := make([]string, 0, len(c.blobs))
+	for blob := range c.blobs {
+		blobs = append(blobs, blob)
+	}
+	sort.Strings(blobs)
+	for _, blob := range blobs {
+		exists, err := ph.Client.BlobExists(to, blob)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			report.ExistingBlobs++
+			continue
+		}
+		mounted, err := ph.Client.MountBlob(to, from, blob)
+		if err != nil {
+			return nil, err
+		}
+		if mounted {
+			report.MountedBlobs++
+			continue
+		}
+		if err = ph.Client.CopyBlob(to, from, blob); err != nil {
+			return nil, err
+		}
+		report.CopiedBlobs++
+	}
+	var pushed []string
+	for _, child := range c.children {
+		_, err := ph.Client.HeadManifest(to, child.Digest)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, registry_client.ErrNotFound) {
+			_, err = ph.Client.PutManifest(to, child.Digest, child)
+		}
+		if err != nil {
+			return pushed, err
+		}
+		pushed = append(pushed, child.Digest)
+		report.Manifests++
+	}
+	return pushed, nil
+}
+
+// targetLocks serializes promotions to the same repository, so the overwrite check and the final PUT of one
+// promotion do not interleave with another, and a rollback does not delete child manifests another promotion
+// found existing and relies on; locks are dropped once nobody waits for them
+type targetLocks struct {
+	mu    sync.Mutex
+	locks map[string]*targetLock
+}
+
+type targetLock struct {
+	sync.Mutex
+	waiters int
+}
+
+func (tl *targetLocks) lock(target string) func() {
+	tl.mu.Lock()
+	if tl.locks == nil {
+		tl.locks = make(map[string]*targetLock)
+	}
+	l, ok := tl.locks[target]
+	if !ok {
+		l = &targetLock{}
+		tl.locks[target] = l
+	}
+	l.waiters++
+	tl.mu.Unlock()
+	l.Lock()
+	return func() {
+		l.Unlock()
+		tl.mu.Lock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(tl.locks, target)
+		}
+		tl.mu.Unlock()
+	}
+}
+
+func (ph *Handler) rollback(repo string, pushed []string) {
+	for _, child := range pushed {
+		if err := ph.Client.DeleteManifest(repo, child); err != nil {
+			log.Printf("[ERROR at promote.Handler.rollback]: %s@%s: %v", repo, child, err)
+		}
+	}
+}
+
+// Promote pushes the source manifest under the target tag; for another repository its blobs are mounted
+// and child manifests pushed first, the target tag is moved by the last request only. Promotions to the same
+// repository run one at a time, so a tag found absent is not pushed by another promotion meanwhile
+func (ph *Handler) Promote(req *Request) (*Report, error) {
+	report := &Report{Source: req.Source, Target: req.Target}
+	from, ref, err := parseSource(req.Source)
+	if err != nil {
+		return report, err
+	}
+	to, tag, err := parseTarget(req.Target)
+	if err != nil {
+		return report, err
+	}
+	unlock := ph.targets.lock(to)
+	defer unlock()
+	src, err := ph.Client.GetManifest(from, ref)
+	if err != nil {
+		return report, err
+	}
+	if src.Digest == "" {
+		src.Digest = digest.FromBytes(src.Data).String()
+	}
+	report.Digest, report.MediaType = src.Digest, src.MediaType
+	current, err := ph.Client.HeadManifest(to, tag)
+	switch {
+	case err == nil && current == src.Digest:
+		report.Result = ResultUnchanged
+		return report, nil
+	case err == nil:
+		report.PreviousDigest = current
+		if !req.Overwrite {
+			return report, ErrTargetExists
+		}
+	case !errors.Is(err, registry_client.ErrNotFound):
+		return report, err
+	}
+	var pushed []string
+	if from != to {
+		c, err := ph.collect(from, src)
+		if err != nil {
+			return report, err
+		}
+		release, err := ph.Quota.Admit(to, c.blobs)
+		if err != nil {
+			return report, err
+		}
+		defer release()
+		pushed, err = ph.transfer(from, to, c, report)
+		if err != nil {
+			ph.rollback(to, pushed)
+			return report, err
+		}
+	}
+	if _, err = ph.Client.PutManifest(to, tag, src); err != nil {
+		ph.rollback(to, pushed)
+		return report, err
+	}
+	report.Result = ResultPromoted
+	return report, nil
+}
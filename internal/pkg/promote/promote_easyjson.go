@@ -0,0 +1,228 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package promote
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson806722aeDecodeRegistryCleanerAgentInternalPkgPromote(in *jlexer.Lexer, out *Request) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "source":
+			out.Source = string(in.String())
+		case "target":
+			out.Target = string(in.String())
+		case "overwrite":
+			out.Overwrite = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson806722aeEncodeRegistryCleanerAgentInternalPkgPromote(out *jwriter.Writer, in Request) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"overwrite\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Overwrite))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Request) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson806722aeEncodeRegistryCleanerAgentInternalPkgPromote(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Request) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson806722aeEncodeRegistryCleanerAgentInternalPkgPromote(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Request) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson806722aeDecodeRegistryCleanerAgentInternalPkgPromote(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Request) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson806722aeDecodeRegistryCleanerAgentInternalPkgPromote(l, v)
+}
+func easyjson806722aeDecodeRegistryCleanerAgentInternalPkgPromote1(in *jlexer.Lexer, out *Report) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "source":
+			out.Source = string(in.String())
+		case "target":
+			out.Target = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "result":
+			out.Result = string(in.String())
+		case "previousDigest":
+			out.PreviousDigest = string(in.String())
+		case "manifests":
+			out.Manifests = int(in.Int())
+		case "mountedBlobs":
+			out.MountedBlobs = int(in.Int())
+		case "copiedBlobs":
+			out.CopiedBlobs = int(in.Int())
+		case "existingBlobs":
+			out.ExistingBlobs = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson806722aeEncodeRegistryCleanerAgentInternalPkgPromote1(out *jwriter.Writer, in Report) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix)
+		out.String(string(in.Result))
+	}
+	if in.PreviousDigest != "" {
+		const prefix string = ",\"previousDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.PreviousDigest))
+	}
+	{
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		out.Int(int(in.Manifests))
+	}
+	{
+		const prefix string = ",\"mountedBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.MountedBlobs))
+	}
+	{
+		const prefix string = ",\"copiedBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.CopiedBlobs))
+	}
+	{
+		const prefix string = ",\"existingBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.ExistingBlobs))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Report) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson806722aeEncodeRegistryCleanerAgentInternalPkgPromote1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Report) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson806722aeEncodeRegistryCleanerAgentInternalPkgPromote1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Report) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson806722aeDecodeRegistryCleanerAgentInternalPkgPromote1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Report) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson806722aeDecodeRegistryCleanerAgentInternalPkgPromote1(l, v)
+}
@@ -0,0 +1,143 @@
+package promote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/quota"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"registry-cleaner-agent/internal/pkg/status"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultAuditPageSize = 100
+	MaxAuditPageSize     = 1000
+)
+
+type Handler struct {
+	Client        *registry_client.Client
+	Quota         *quota.Handler
+	StatusManager *status.Manager
+	// AuditRetention limits the audit log kept in the status storage
+	AuditRetention AuditRetention
+	targets        targetLocks
+}
+
+// AuditRetention limits the audit log; zero values disable the respective rule
+type AuditRetention struct {
+	// MaxAge deletes entries recorded earlier
+	MaxAge time.Duration
+	// MaxEntries keeps only the latest entries
+	MaxEntries int
+}
+
+func InitPromoteHandler(client *registry_client.Client, qh *quota.Handler, stm *status.Manager) (*Handler, error) {
+	if client == nil || qh == nil || stm == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		Client:        client,
+		Quota:         qh,
+		StatusManager: stm,
+	}, nil
+}
+
+// audit records every promotion attempt, failed ones included
+func (ph *Handler) audit(r *http.Request, report *Report, err error) {
+	entry := &status.AuditEntry{
+		Action:         ActionPromote,
+		Client:         r.RemoteAddr,
+		Source:         report.Source,
+		Target:         report.Target,
+		Digest:         report.Digest,
+		PreviousDigest: report.PreviousDigest,
+		Result:         report.Result,
+		Manifests:      report.Manifests,
+		MountedBlobs:   report.MountedBlobs,
+		CopiedBlobs:    report.CopiedBlobs,
+	}
+	if err != nil {
+		entry.Result = ResultFailed
+		entry.Error = err.Error()
+	}
+	log.Printf("[INFO at promote.Handler.audit]: %s %s -> %s (%s) by %s: %s %s",
+		entry.Action, entry.Source, entry.Target, entry.Digest, entry.Client, entry.Result, entry.Error)
+	if err = ph.StatusManager.AddAuditEntry(entry); err != nil {
+		log.Printf("[ERROR at promote.Handler.audit]: %v", err)
+		return
+	}
+	var before time.Time
+	if ph.AuditRetention.MaxAge > 0 {
+		before = time.Now().Add(-ph.AuditRetention.MaxAge)
+	}
+	if _, err = ph.StatusManager.PruneAuditEntries(before, ph.AuditRetention.MaxEntries); err != nil {
+		log.Printf("[ERROR at promote.Handler.audit]: %v", err)
+	}
+}
+
+// PromoteHandler copies a manifest under a new tag, possibly of another repository
+func (ph *Handler) PromoteHandler(w http.ResponseWriter, r *http.Request) {
+	req := &Request{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	report, err := ph.Promote(req)
+	ph.audit(r, report, err)
+	switch {
+	case errors.Is(err, ErrInvalidSource), errors.Is(err, ErrInvalidTarget):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, registry_client.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrTargetExists):
+		agent_errors.WriteRegistryError(w, http.StatusConflict, agent_errors.CodeDenied,
+			"target tag points to another manifest, use overwrite to move it", report.PreviousDigest)
+	case errors.Is(err, quota.ErrExceeded), errors.Is(err, registry_client.ErrDenied):
+		agent_errors.WriteRegistryError(w, http.StatusForbidden, agent_errors.CodeDenied, err.Error(), nil)
+	case err != nil:
+		log.Printf("[ERROR at promote.Handler.PromoteHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		http_response.WriteJson(w, report)
+	}
+}
+
+// AuditHandler lists recorded promotions oldest first, "n" and "last" paginate by entry id
+func (ph *Handler) AuditHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	n := DefaultAuditPageSize
+	if value := query.Get("n"); value != "" {
+		var err error
+		n, err = strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid page size", http.StatusBadRequest)
+			return
+		}
+		if n > MaxAuditPageSize {
+			n = MaxAuditPageSize
+		}
+	}
+	// One more entry tells whether another page follows
+	entries, err := ph.StatusManager.ListAuditEntries(query.Get("last"), n+1)
+	if err != nil {
+		log.Printf("[ERROR at promote.Handler.AuditHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(entries) > n {
+		entries = entries[:n]
+		next := url.Values{}
+		next.Set("n", strconv.Itoa(n))
+		next.Set("last", entries[n-1].ID)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	}
+	http_response.WriteJson(w, &status.AuditLog{Entries: entries})
+}
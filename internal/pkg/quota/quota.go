@@ -0,0 +1,98 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rule is a quota declared in the agent config
+type Rule struct {
+	// Prefix is a repository name or namespace, covering every repository below it
+	Prefix string `toml:"prefix"`
+	// Limit accepts plain bytes or units: KB, MB, GB, TB (powers of 1000), KiB, MiB, GiB, TiB (powers of 1024)
+	Limit string `toml:"limit"`
+}
+
+type Quota struct {
+	Prefix string
+	Limit  int64
+}
+
+//easyjson:json
+type Usage struct {
+	Prefix       string   `json:"prefix"`
+	Limit        int64    `json:"limit"`
+	Used         int64    `json:"used"`
+	Available    int64    `json:"available"`
+	Exceeded     bool     `json:"exceeded"`
+	Repositories []string `json:"repositories"`
+}
+
+//easyjson:json
+type UsageList struct {
+	Quotas []Usage `json:"quotas"`
+}
+
+var (
+	ErrInvalidLimit = errors.New("invalid quota limit")
+	ErrExceeded     = errors.New("repository storage quota exceeded")
+)
+
+var sizeUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+}
+
+func ParseSize(size string) (int64, error) {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	numEnd := strings.IndexFunc(size, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if numEnd < 0 {
+		numEnd = len(size)
+	}
+	unit, ok := sizeUnits[strings.TrimSpace(size[numEnd:])]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit in %q", ErrInvalidLimit, size)
+	}
+	value, err := strconv.ParseFloat(size[:numEnd], 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidLimit, size)
+	}
+	return int64(value * float64(unit)), nil
+}
+
+// ParseRules validates the rules, most specific prefixes go first
+func ParseRules(rules []Rule) ([]Quota, error) {
+	quotas := make([]Quota, 0, len(rules))
+	for _, rule := range rules {
+		prefix := strings.Trim(rule.Prefix, "/")
+		if prefix == "" {
+			return nil, fmt.Errorf("%w: empty prefix", ErrInvalidLimit)
+		}
+		limit, err := ParseSize(rule.Limit)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, Quota{Prefix: prefix, Limit: limit})
+	}
+	sort.SliceStable(quotas, func(i, j int) bool {
+		return len(quotas[i].Prefix) > len(quotas[j].Prefix)
+	})
+	return quotas, nil
+}
+
+func (q *Quota) Covers(repo string) bool {
+	return repo == q.Prefix || strings.HasPrefix(repo, q.Prefix+"/")
+}
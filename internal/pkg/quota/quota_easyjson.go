@@ -0,0 +1,250 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package quota
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonD24230d6DecodeRegistryCleanerAgentInternalPkgQuota(in *jlexer.Lexer, out *UsageList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "quotas":
+			if in.IsNull() {
+				in.Skip()
+				out.Quotas = nil
+			} else {
+				in.Delim('[')
+				if out.Quotas == nil {
+					if !in.IsDelim(']') {
+						out.Quotas = make([]Usage, 0, 0)
+					} else {
+						out.Quotas = []Usage{}
+					}
+				} else {
+					out.Quotas = (out.Quotas)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Usage
+					(v1).UnmarshalEasyJSON(in)
+					out.Quotas = append(out.Quotas, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD24230d6EncodeRegistryCleanerAgentInternalPkgQuota(out *jwriter.Writer, in UsageList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"quotas\":"
+		out.RawString(prefix[1:])
+		if in.Quotas == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Quotas {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UsageList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD24230d6EncodeRegistryCleanerAgentInternalPkgQuota(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UsageList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD24230d6EncodeRegistryCleanerAgentInternalPkgQuota(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UsageList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD24230d6DecodeRegistryCleanerAgentInternalPkgQuota(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UsageList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD24230d6DecodeRegistryCleanerAgentInternalPkgQuota(l, v)
+}
+func easyjsonD24230d6DecodeRegistryCleanerAgentInternalPkgQuota1(in *jlexer.Lexer, out *Usage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "prefix":
+			out.Prefix = string(in.String())
+		case "limit":
+			out.Limit = int64(in.Int64())
+		case "used":
+			out.Used = int64(in.Int64())
+		case "available":
+			out.Available = int64(in.Int64())
+		case "exceeded":
+			out.Exceeded = bool(in.Bool())
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Repositories = append(out.Repositories, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD24230d6EncodeRegistryCleanerAgentInternalPkgQuota1(out *jwriter.Writer, in Usage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"prefix\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Prefix))
+	}
+	{
+		const prefix string = ",\"limit\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Limit))
+	}
+	{
+		const prefix string = ",\"used\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Used))
+	}
+	{
+		const prefix string = ",\"available\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Available))
+	}
+	{
+		const prefix string = ",\"exceeded\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Exceeded))
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Repositories {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Usage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD24230d6EncodeRegistryCleanerAgentInternalPkgQuota1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Usage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD24230d6EncodeRegistryCleanerAgentInternalPkgQuota1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Usage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD24230d6DecodeRegistryCleanerAgentInternalPkgQuota1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Usage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD24230d6DecodeRegistryCleanerAgentInternalPkgQuota1(l, v)
+}
@@ -0,0 +1,459 @@
+package quota
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"regexp"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Handler struct {
+	FSAnalyzer *fs_analyzer.Analyzer
+	Quotas     []Quota
+	// states holds one entry per quota prefix, the map itself is never modified after init
+	states map[string]*state
+}
+
+// state caches the measurement of a quota and bytes admitted but not measured yet
+type state struct {
+	// measureMu serializes measuring of the quota, other quotas are measured concurrently
+	measureMu *sync.Mutex
+	mu        *sync.Mutex
+	m         *measurement
+	// reserved counts bytes of admitted requests still being committed
+	reserved int64
+	// commits are blobs linked since the measurement started, counted on top of it until the next one
+	commits        []commit
+	committed      int64
+	committedBlobs map[string]struct{}
+}
+
+// commit is a blob linked to a repository of the quota, manifests pushed by tag have no digest
+type commit struct {
+	at     time.Time
+	digest string
+	size   int64
+}
+
+// measurement keeps the blob set of a quota to recognise already stored blobs
+type measurement struct {
+	usage      Usage
+	blobs      map[string]struct{}
+	measuredAt time.Time
+}
+
+const UsageCacheTTL = 30 * time.Second
+
+var (
+	blobUploadRegexp = regexp.MustCompile(`^/v2/(.+)/blobs/uploads/([^/]*)$`)
+	manifestRegexp   = regexp.MustCompile(`^/v2/(.+)/manifests/[^/]+$`)
+)
+
+func InitQuotaHandler(fsa *fs_analyzer.Analyzer, rules []Rule) (*Handler, error) {
+	if fsa == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	quotas, err := ParseRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]*state, len(quotas))
+	for _, q := range quotas {
+		states[q.Prefix] = &state{measureMu: &sync.Mutex{}, mu: &sync.Mutex{}, committedBlobs: map[string]struct{}{}}
+	}
+	return &Handler{
+		FSAnalyzer: fsa,
+		Quotas:     quotas,
+		states:     states,
+	}, nil
+}
+
+// Match returns the most specific quota covering the repository
+func (qh *Handler) Match(repo string) *Quota {
+	for i := range qh.Quotas {
+		if qh.Quotas[i].Covers(repo) {
+			return &qh.Quotas[i]
+		}
+	}
+	return nil
+}
+
+func (qh *Handler) measure(q *Quota) (*measurement, error) {
+	repositories, err := qh.FSAnalyzer.ListRepositories()
+	if err != nil {
+		return nil, err
+	}
+	m := &measurement{
+		usage:      Usage{Prefix: q.Prefix, Limit: q.Limit, Repositories: []string{}},
+		blobs:      make(map[string]struct{}),
+		measuredAt: time.Now(),
+	}
+	for _, repo := range repositories {
+		// Repositories under a more specific quota are accounted there
+		if qh.Match(repo) != q {
+			continue
+		}
+		m.usage.Repositories = append(m.usage.Repositories, repo)
+		blobs, err := qh.FSAnalyzer.ListRepositoryBlobs(repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range blobs {
+			m.blobs[blob] = struct{}{}
+		}
+	}
+	digests := make([]string, 0, len(m.blobs))
+	for blob := range m.blobs {
+		digests = append(digests, blob)
+	}
+	m.usage.Used, err = qh.FSAnalyzer.GetExistingBlobsSize(digests)
+	if err != nil {
+		return nil, err
+	}
+	m.usage.Available = q.Limit - m.usage.Used
+	if m.usage.Available < 0 {
+		m.usage.Available = 0
+	}
+	m.usage.Exceeded = m.usage.Used >= q.Limit
+	return m, nil
+}
+
+func (s *state) cached() *measurement {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil || time.Since(s.m.measuredAt) >= UsageCacheTTL {
+		return nil
+	}
+	return s.m
+}
+
+func (qh *Handler) getMeasurement(q *Quota) (*measurement, error) {
+	s := qh.states[q.Prefix]
+	s.measureMu.Lock()
+	defer s.measureMu.Unlock()
+	if m := s.cached(); m != nil {
+		return m, nil
+	}
+	m, err := qh.measure(q)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.m = m
+	s.rebase()
+	s.mu.Unlock()
+	return m, nil
+}
+
+// rebase keeps commits the current measurement may have missed, it started before they were made
+func (s *state) rebase() {
+	commits := s.commits[:0]
+	s.committed = 0
+	s.committedBlobs = make(map[string]struct{})
+	for _, c := range s.commits {
+		if c.at.Before(s.m.measuredAt) {
+			continue
+		}
+		commits = append(commits, c)
+		s.committed += c.size
+		if c.digest != "" {
+			s.committedBlobs[c.digest] = struct{}{}
+		}
+	}
+	s.commits = commits
+}
+
+// known tells whether the blob is accounted in the quota already
+func (qh *Handler) known(q *Quota, m *measurement, digest string) bool {
+	if _, ok := m.blobs[digest]; ok {
+		return true
+	}
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.committedBlobs[digest]
+	return ok
+}
+
+// used counts measured bytes and bytes admitted to requests still in progress
+func (qh *Handler) used(q *Quota, m *measurement) int64 {
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return m.usage.Used + s.committed + s.reserved
+}
+
+// reserve admits incoming bytes unless they exceed the quota together with bytes admitted to concurrent
+// requests; the reservation is held until release
+func (qh *Handler) reserve(q *Quota, m *measurement, incoming int64) (used int64, ok bool) {
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	used = m.usage.Used + s.committed + s.reserved
+	if used+incoming > q.Limit {
+		return used, false
+	}
+	s.reserved += incoming
+	return used, true
+}
+
+// release drops the reservation of a finished request
+func (qh *Handler) release(q *Quota, incoming int64) {
+	if incoming == 0 {
+		return
+	}
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reserved -= incoming
+}
+
+// commit adds a linked blob to the cached measurement, so pushes do not make the next request measure again;
+// the next measurement after UsageCacheTTL counts it from the storage
+func (qh *Handler) commit(q *Quota, digest string, size int64) {
+	if size == 0 {
+		return
+	}
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if digest != "" {
+		if _, ok := s.committedBlobs[digest]; ok {
+			return
+		}
+		s.committedBlobs[digest] = struct{}{}
+	}
+	s.commits = append(s.commits, commit{at: time.Now(), digest: digest, size: size})
+	s.committed += size
+}
+
+func (qh *Handler) Usage(q *Quota) (Usage, error) {
+	m, err := qh.getMeasurement(q)
+	if err != nil {
+		return Usage{}, err
+	}
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage := m.usage
+	usage.Used += s.committed
+	usage.Available = q.Limit - usage.Used
+	if usage.Available < 0 {
+		usage.Available = 0
+	}
+	usage.Exceeded = usage.Used >= q.Limit
+	return usage, nil
+}
+
+// pushedDigest returns the blob a push request links: the digest completing an upload or the mounted blob
+func pushedDigest(r *http.Request) string {
+	if r.Method == http.MethodPost {
+		return r.URL.Query().Get("mount")
+	}
+	return r.URL.Query().Get("digest")
+}
+
+// statusRecorder keeps the status code of the proxied response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	sr.status = status
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+func (sr *statusRecorder) Flush() {
+	if f, ok := sr.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// incomingBytes estimates how many bytes the push request adds to the quota. Chunked uploads send no
+// content length, so bytes already received by the upload session are taken from the registry storage.
+func (qh *Handler) incomingBytes(r *http.Request, repo, uuid string, m *measurement) int64 {
+	incoming := r.ContentLength
+	if incoming < 0 {
+		incoming = 0
+	}
+	if uuid != "" {
+		if uploaded, err := qh.FSAnalyzer.GetUploadSize(repo, uuid); err == nil {
+			incoming += uploaded
+		}
+	}
+	digest := pushedDigest(r)
+	if digest == "" {
+		return incoming
+	}
+	if qh.known(qh.Match(repo), m, digest) {
+		return 0
+	}
+	if size, err := qh.FSAnalyzer.GetBlobSize(digest); err == nil && size > incoming {
+		// Cross-repository mount or an already uploaded blob getting linked
+		return size
+	}
+	return incoming
+}
+
+// Admit checks blobs about to be linked to the repository by the agent itself, bypassing Enforce;
+// blobs already accounted in the quota are free. The returned release must be called once the blobs
+// are linked or the push is abandoned; admitted blobs are counted as linked, as abandoned pushes keep blobs
+// linked before they failed, until the next measurement.
+func (qh *Handler) Admit(repo string, blobs map[string]int64) (release func(), err error) {
+	release = func() {}
+	q := qh.Match(repo)
+	if q == nil {
+		return release, nil
+	}
+	m, err := qh.getMeasurement(q)
+	if err != nil {
+		log.Printf("[ERROR at quota.Handler.Admit]: %v", err)
+		return release, nil
+	}
+	var incoming int64
+	unknown := make(map[string]int64)
+	for digest, size := range blobs {
+		if !qh.known(q, m, digest) {
+			unknown[digest] = size
+			incoming += size
+		}
+	}
+	used, ok := qh.reserve(q, m, incoming)
+	if !ok {
+		return release, fmt.Errorf("%w: quota %s: %d + %d > %d bytes", ErrExceeded, q.Prefix, used, incoming, q.Limit)
+	}
+	return func() {
+		for digest, size := range unknown {
+			qh.commit(q, digest, size)
+		}
+		qh.release(q, incoming)
+	}, nil
+}
+
+// Check tells whether the repository can take the given number of bytes, without reserving them
+func (qh *Handler) Check(repo string, incoming int64) error {
+	q := qh.Match(repo)
+	if q == nil {
+		return nil
+	}
+	m, err := qh.getMeasurement(q)
+	if err != nil {
+		log.Printf("[ERROR at quota.Handler.Check]: %v", err)
+		return nil
+	}
+	if used := qh.used(q, m); used+incoming > q.Limit {
+		return fmt.Errorf("%w: quota %s: %d + %d > %d bytes", ErrExceeded, q.Prefix, used, incoming, q.Limit)
+	}
+	return nil
+}
+
+// Enforce rejects pushes to repositories whose quota would be exceeded
+func (qh *Handler) Enforce(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var match []string
+		var uuid string
+		switch r.Method {
+		case http.MethodPost, http.MethodPatch, http.MethodPut:
+			if match = blobUploadRegexp.FindStringSubmatch(r.URL.Path); match != nil {
+				uuid = match[2]
+			} else if r.Method == http.MethodPut {
+				match = manifestRegexp.FindStringSubmatch(r.URL.Path)
+			}
+		}
+		if match == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		repo := match[1]
+		q := qh.Match(repo)
+		if q == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		m, err := qh.getMeasurement(q)
+		if err != nil {
+			// Failing open keeps the registry writable when the storage can not be measured
+			log.Printf("[ERROR at quota.Handler.Enforce]: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		incoming := qh.incomingBytes(r, repo, uuid, m)
+		// PATCH only appends to the upload session, bytes are reserved when POST or PUT links the blob
+		commits := r.Method != http.MethodPatch
+		var used int64
+		var admitted bool
+		if commits {
+			used, admitted = qh.reserve(q, m, incoming)
+		} else {
+			used = qh.used(q, m)
+			admitted = used+incoming <= q.Limit
+		}
+		if admitted && r.Method == http.MethodPost && used >= q.Limit {
+			qh.release(q, incoming)
+			admitted = false
+		}
+		if !admitted {
+			log.Printf("[INFO at quota.Handler.Enforce]: %s %s denied, quota %s: %d + %d > %d bytes",
+				r.Method, r.URL.Path, q.Prefix, used, incoming, q.Limit)
+			agent_errors.WriteRegistryError(w, http.StatusForbidden, agent_errors.CodeDenied,
+				"repository storage quota exceeded", map[string]interface{}{
+					"prefix":    q.Prefix,
+					"limit":     q.Limit,
+					"used":      used,
+					"requested": incoming,
+				})
+			return
+		}
+		if !commits {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.status == http.StatusCreated {
+			qh.commit(q, pushedDigest(r), incoming)
+		}
+		qh.release(q, incoming)
+	})
+}
+
+func (qh *Handler) QuotasGetHandler(w http.ResponseWriter, _ *http.Request) {
+	list := UsageList{Quotas: make([]Usage, 0, len(qh.Quotas))}
+	for i := range qh.Quotas {
+		usage, err := qh.Usage(&qh.Quotas[i])
+		if err != nil {
+			log.Printf("[ERROR at quota.Handler.QuotasGetHandler]: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		list.Quotas = append(list.Quotas, usage)
+	}
+	sort.Slice(list.Quotas, func(i, j int) bool {
+		return list.Quotas[i].Prefix < list.Quotas[j].Prefix
+	})
+	http_response.WriteJson(w, &list)
+}
+
+func (qh *Handler) RepositoryQuotaGetHandler(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+	q := qh.Match(repo)
+	if q == nil {
+		http.Error(w, "no quota configured for repository "+repo, http.StatusNotFound)
+		return
+	}
+	usage, err := qh.Usage(q)
+	if err != nil {
+		log.Printf("[ERROR at quota.Handler.RepositoryQuotaGetHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http_response.WriteJson(w, &usage)
+}
@@ -0,0 +1,214 @@
+package quota
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testBlob returns a digest made of a repeated hex character
+func testBlob(c string) string {
+	return "sha256:" + strings.Repeat(c, 64)
+}
+
+// storeBlob writes a blob of the given size and links it as a layer of the repository
+func storeBlob(t *testing.T, root, repo, digest string, size int) {
+	t.Helper()
+	hex := strings.TrimPrefix(digest, "sha256:")
+	blobDir := path.Join(root, fs_analyzer.BlobsPath, "sha256", hex[:2], hex)
+	linkDir := path.Join(root, fs_analyzer.RepositoriesPath, repo, fs_analyzer.LayersDir, "sha256", hex)
+	for _, dir := range []string{blobDir, linkDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(path.Join(blobDir, fs_analyzer.BlobFilename), make([]byte, size), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(linkDir, fs_analyzer.LinkFilename), []byte(digest), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestHandler limits team/ to 100 bytes, team/api holds 40 bytes in blob "a"
+func newTestHandler(t *testing.T) (*Handler, string) {
+	root := t.TempDir()
+	storeBlob(t, root, "team/api", testBlob("a"), 40)
+	qh, err := InitQuotaHandler(fs_analyzer.NewFSAnalyzer(root), []Rule{{Prefix: "team", Limit: "100"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return qh, root
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    int64
+		wantErr bool
+	}{
+		{"100", 100, false},
+		{"1KB", 1000, false},
+		{"1 KiB", 1024, false},
+		{"1.5gib", 3 << 29, false},
+		{"2TB", 2000 * 1000 * 1000 * 1000, false},
+		{"0", 0, true},
+		{"-1MB", 0, true},
+		{"10 parsecs", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := ParseSize(tt.size)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidLimit) {
+					t.Fatalf("err = %v, want %v", err, ErrInvalidLimit)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	tests := []struct {
+		name string
+		repo string
+		// admitted are released before blobs are admitted
+		admitted map[string]int64
+		blobs    map[string]int64
+		wantErr  bool
+	}{
+		{"stored blob is free", "team/web", nil, map[string]int64{testBlob("a"): 40}, false},
+		{"fits the quota", "team/api", nil, map[string]int64{testBlob("b"): 60}, false},
+		{"exceeds the quota", "team/api", nil, map[string]int64{testBlob("b"): 61}, true},
+		{"uncovered repository", "other/api", nil, map[string]int64{testBlob("b"): 1000}, false},
+		{"released blobs count as stored", "team/api", map[string]int64{testBlob("b"): 50},
+			map[string]int64{testBlob("c"): 11}, true},
+		{"released blob is free", "team/api", map[string]int64{testBlob("b"): 50},
+			map[string]int64{testBlob("b"): 50, testBlob("c"): 10}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qh, _ := newTestHandler(t)
+			if tt.admitted != nil {
+				release, err := qh.Admit("team/api", tt.admitted)
+				if err != nil {
+					t.Fatal(err)
+				}
+				release()
+			}
+			release, err := qh.Admit(tt.repo, tt.blobs)
+			defer release()
+			if tt.wantErr != errors.Is(err, ErrExceeded) {
+				t.Errorf("err = %v, want exceeded %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdmitReservesUntilRelease(t *testing.T) {
+	qh, _ := newTestHandler(t)
+	release, err := qh.Admit("team/api", map[string]int64{testBlob("b"): 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = qh.Admit("team/web", map[string]int64{testBlob("c"): 20}); !errors.Is(err, ErrExceeded) {
+		t.Errorf("concurrent admission: err = %v, want %v", err, ErrExceeded)
+	}
+	release()
+	usage, err := qh.Usage(qh.Match("team/api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Used != 90 || usage.Available != 10 {
+		t.Errorf("used %d, available %d, want 90 and 10", usage.Used, usage.Available)
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		length   int64
+		upstream int
+		want     int
+		wantUsed int64
+	}{
+		{"blob upload completed", "PUT", "/v2/team/api/blobs/uploads/u1?digest=" + testBlob("b"), 30,
+			http.StatusCreated, http.StatusCreated, 70},
+		{"blob upload failed", "PUT", "/v2/team/api/blobs/uploads/u1?digest=" + testBlob("b"), 30,
+			http.StatusBadRequest, http.StatusBadRequest, 40},
+		{"stored blob", "PUT", "/v2/team/web/blobs/uploads/u1?digest=" + testBlob("a"), 40,
+			http.StatusCreated, http.StatusCreated, 40},
+		{"blob upload over quota", "PUT", "/v2/team/api/blobs/uploads/u1?digest=" + testBlob("b"), 61,
+			http.StatusCreated, http.StatusForbidden, 40},
+		{"chunk over quota", "PATCH", "/v2/team/api/blobs/uploads/u1", 61,
+			http.StatusAccepted, http.StatusForbidden, 40},
+		{"chunk is not committed", "PATCH", "/v2/team/api/blobs/uploads/u1", 30,
+			http.StatusAccepted, http.StatusAccepted, 40},
+		{"manifest push", "PUT", "/v2/team/api/manifests/latest", 10,
+			http.StatusCreated, http.StatusCreated, 50},
+		{"uncovered repository", "PUT", "/v2/other/manifests/latest", 1000,
+			http.StatusCreated, http.StatusCreated, 40},
+		{"pull", "GET", "/v2/team/api/manifests/latest", 0,
+			http.StatusOK, http.StatusOK, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qh, _ := newTestHandler(t)
+			handler := qh.Enforce(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.upstream)
+			}))
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(strings.Repeat("x", int(tt.length))))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status %d, want %d", w.Code, tt.want)
+			}
+			usage, err := qh.Usage(qh.Match("team/api"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if usage.Used != tt.wantUsed {
+				t.Errorf("used %d, want %d", usage.Used, tt.wantUsed)
+			}
+		})
+	}
+}
+
+func TestCommitsBeforeMeasurementAreDropped(t *testing.T) {
+	qh, root := newTestHandler(t)
+	q := qh.Match("team/api")
+	release, err := qh.Admit("team/api", map[string]int64{testBlob("b"): 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	storeBlob(t, root, "team/api", testBlob("b"), 30)
+	s := qh.states[q.Prefix]
+	s.mu.Lock()
+	s.m.measuredAt = time.Now().Add(-UsageCacheTTL)
+	s.mu.Unlock()
+	usage, err := qh.Usage(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Used != 70 {
+		t.Errorf("used %d, want the blob counted once, 70", usage.Used)
+	}
+}
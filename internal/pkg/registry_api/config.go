@@ -0,0 +1,116 @@
+package registry_api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/distribution"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"strings"
+)
+
+// getManifest fetches the manifest together with its digest, manifests requested by digest are cached
+// and revalidated with HEAD so deleted manifests are not served from memory
+func (rah *RegistryApiHandler) getManifest(repo, reference string) (distribution.Manifest, string, error) {
+	if m, ok := rah.cachedManifest(repo, reference); ok {
+		if _, err := rah.headDigest(repo, reference); err != nil {
+			rah.manifestCache.Remove(repo + "@" + reference)
+			return nil, "", err
+		}
+		return m, reference, nil
+	}
+	mchan := make(chan manifest.Result)
+	go manifest.GetManifest(rah.Client.ManifestUrl(repo, reference).String(), mchan)
+	result := <-mchan
+	close(mchan)
+	if err := resultError(result); err != nil {
+		return nil, "", err
+	}
+	digest := result.ApiResp.Header.Get("Docker-Content-Digest")
+	if digest != "" {
+		rah.manifestCache.Add(repo+"@"+digest, result.Manifest)
+	}
+	return result.Manifest, digest, nil
+}
+
+// selectPlatform finds the child manifest for "os/architecture[/variant]"
+func selectPlatform(list distribution.Manifest, platform string) (string, bool) {
+	for _, descriptor := range manifest.ListDescriptors(list) {
+		candidate := descriptor.Platform.OS + "/" + descriptor.Platform.Architecture
+		if descriptor.Platform.Variant != "" && strings.Count(platform, "/") == 2 {
+			candidate += "/" + descriptor.Platform.Variant
+		}
+		if candidate == platform {
+			return descriptor.Digest.String(), true
+		}
+	}
+	return "", false
+}
+
+var (
+	errPlatformRequired = errors.New("platform parameter is required for manifest lists, e.g. linux/amd64")
+	errPlatformNotFound = errors.New("platform not found in manifest list")
+	errNoImageConfig    = errors.New("manifest has no image configuration")
+)
+
+// resolveImage fetches a single-platform manifest and its config, platform selects a child of manifest lists
+func (rah *RegistryApiHandler) resolveImage(repo, reference, platform string) (*manifest.DiffSide, error) {
+	m, digest, err := rah.getManifest(repo, reference)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.IsList(m) {
+		if platform == "" {
+			return nil, errPlatformRequired
+		}
+		childDigest, ok := selectPlatform(m, platform)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errPlatformNotFound, platform)
+		}
+		m, digest, err = rah.getManifest(repo, childDigest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	descriptor, ok := manifest.ConfigDescriptor(m)
+	if !ok {
+		return nil, errNoImageConfig
+	}
+	config, err := rah.getConfig(repo, descriptor)
+	if err != nil {
+		return nil, err
+	}
+	return &manifest.DiffSide{Name: repo, Reference: reference, Digest: digest, Manifest: m, Config: config}, nil
+}
+
+// writeImageError replies to resolveImage failures
+func writeImageError(w http.ResponseWriter, method string, err error) {
+	switch {
+	case errors.Is(err, errPlatformRequired):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, errPlatformNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, errNoImageConfig):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		if !isUpstreamError(err) {
+			log.Printf("[ERROR at RegistryApiHandler.%s]: %v", method, err)
+		}
+		writeError(w, err)
+	}
+}
+
+// ManifestConfigHandler decodes the image configuration, manifest lists require "platform" parameter
+func (rah *RegistryApiHandler) ManifestConfigHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	image, err := rah.resolveImage(vars["repo"], vars["tag"], r.URL.Query().Get("platform"))
+	if err != nil {
+		writeImageError(w, "ManifestConfigHandler", err)
+		return
+	}
+	view := manifest.InspectConfig(image.Name, image.Reference, image.Digest, image.Manifest, image.Config)
+	http_response.WriteJson(w, &view)
+}
@@ -0,0 +1,70 @@
+package registry_api
+
+import (
+	"errors"
+	"fmt"
+	"github.com/docker/distribution/reference"
+	"github.com/gorilla/mux"
+	"github.com/opencontainers/go-digest"
+	"net/http"
+	"regexp"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"strings"
+)
+
+var (
+	errInvalidDiffReference = errors.New("invalid reference")
+	anchoredTagRegexp       = regexp.MustCompile("^" + reference.TagRegexp.String() + "$")
+)
+
+// diffReference resolves "from" and "to" parameters: a tag or digest of the repository,
+// or "<name>:<tag>" and "<name>@<digest>" of another repository
+func diffReference(repo, value string) (string, string, error) {
+	if _, err := digest.Parse(value); err == nil {
+		return repo, value, nil
+	}
+	name, ref := repo, value
+	if i := strings.LastIndex(value, "@"); i >= 0 {
+		name, ref = value[:i], value[i+1:]
+		if _, err := digest.Parse(ref); err != nil {
+			return "", "", fmt.Errorf("%w %q: %v", errInvalidDiffReference, value, err)
+		}
+	} else {
+		if i := strings.LastIndex(value, ":"); i > strings.LastIndex(value, "/") {
+			name, ref = value[:i], value[i+1:]
+		}
+		if !anchoredTagRegexp.MatchString(ref) {
+			return "", "", fmt.Errorf("%w %q", errInvalidDiffReference, value)
+		}
+	}
+	if _, err := reference.WithName(name); err != nil {
+		return "", "", fmt.Errorf("%w %q: %v", errInvalidDiffReference, value, err)
+	}
+	return name, ref, nil
+}
+
+// DiffHandler compares images given by "from" and "to" parameters, "platform" selects images of manifest lists
+func (rah *RegistryApiHandler) DiffHandler(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+	query := r.URL.Query()
+	if query.Get("from") == "" || query.Get("to") == "" {
+		http.Error(w, "from and to parameters are required", http.StatusBadRequest)
+		return
+	}
+	sides := make([]*manifest.DiffSide, 2)
+	for i, param := range []string{"from", "to"} {
+		name, ref, err := diffReference(repo, query.Get(param))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sides[i], err = rah.resolveImage(name, ref, query.Get("platform"))
+		if err != nil {
+			writeImageError(w, "DiffHandler", err)
+			return
+		}
+	}
+	diff := manifest.Compare(sides[0], sides[1])
+	http_response.WriteJson(w, &diff)
+}
@@ -1,14 +1,17 @@
 package registry_api
 
 import (
-	"encoding/json"
-	"github.com/tidwall/gjson"
+	"errors"
+	"github.com/gorilla/mux"
 	"log"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
-	"path"
+	"regexp"
+	"registry-cleaner-agent/internal/pkg/cache"
+	"registry-cleaner-agent/internal/pkg/http_response"
 	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
 	"registry-cleaner-agent/internal/pkg/status"
 	"strings"
 	"time"
@@ -17,8 +20,34 @@ import (
 type RegistryApiHandler struct {
 	ApiUrl        *url.URL
 	StatusManager *status.Manager
+	Client        *registry_client.Client
+	// PersistSummaries keeps computed summaries in the status storage across restarts
+	PersistSummaries bool
+	// Referrers attaches signatures, attestations and SBOMs to summaries when set
+	Referrers     ReferrerSource
+	configCache   *cache.LRU
+	summaryCache  *cache.LRU
+	manifestCache *cache.LRU
 }
 
+// ReferrerSource lists manifests attached to a subject manifest
+type ReferrerSource interface {
+	Referrers(repo, digest string) []manifest.Referrer
+}
+
+const (
+	// ConfigCacheSize bounds the number of image config blobs kept in memory
+	ConfigCacheSize = 1024
+	// SummaryCacheSize bounds the number of manifest summaries kept in memory
+	SummaryCacheSize = 4096
+	// ManifestCacheSize bounds the number of parsed manifests kept in memory
+	ManifestCacheSize = 1024
+	// PingTimeout bounds the registry health probe of status and metrics
+	PingTimeout = 5 * time.Second
+)
+
+var manifestPathRegexp = regexp.MustCompile(`^/v2/(.+)/manifests/([^/]+)$`)
+
 func InitApiHandler(apiUrl string, statusManager *status.Manager) (*RegistryApiHandler, error) {
 	parsedUrl, err := url.Parse(apiUrl)
 	if err != nil {
@@ -27,11 +56,16 @@ func InitApiHandler(apiUrl string, statusManager *status.Manager) (*RegistryApiH
 	return &RegistryApiHandler{
 		ApiUrl:        parsedUrl,
 		StatusManager: statusManager,
+		Client:        registry_client.New(parsedUrl),
+		configCache:   cache.NewLRU(ConfigCacheSize),
+		summaryCache:  cache.NewLRU(SummaryCacheSize),
+		manifestCache: cache.NewLRU(ManifestCacheSize),
 	}, nil
 }
 
 func (rah *RegistryApiHandler) ProxyHandler(w http.ResponseWriter, r *http.Request) {
 	proxy := httputil.NewSingleHostReverseProxy(rah.ApiUrl)
+	proxy.ModifyResponse = rah.trackActivity
 	// Update the headers for redirection
 	r.URL.Host = rah.ApiUrl.Host
 	r.URL.Scheme = rah.ApiUrl.Scheme
@@ -41,105 +75,140 @@ func (rah *RegistryApiHandler) ProxyHandler(w http.ResponseWriter, r *http.Reque
 	proxy.ServeHTTP(w, r)
 }
 
-func (rah *RegistryApiHandler) StatusHandler(w http.ResponseWriter, _ *http.Request) {
-	healthUrl, err := url.Parse(rah.ApiUrl.String())
+// trackActivity records successful manifest GETs and PUTs passing through the proxy, and tags resolved with HEAD:
+// clients resolve the tag with HEAD and GET the manifest by digest
+func (rah *RegistryApiHandler) trackActivity(resp *http.Response) error {
+	req := resp.Request
+	match := manifestPathRegexp.FindStringSubmatch(req.URL.Path)
+	if match == nil {
+		return nil
+	}
+	repo, reference := match[1], match[2]
+	digest := resp.Header.Get(registry_client.HeaderContentDigest)
+	// Tags never contain ':', digests always do
+	isTag := !strings.Contains(reference, ":")
+	now := time.Now()
+	var err error
+	switch {
+	case req.Method == http.MethodGet && resp.StatusCode == http.StatusOK && isTag:
+		err = rah.StatusManager.RecordTagPull(repo, reference, digest, now)
+		if err == nil {
+			err = rah.StatusManager.RecordPull(repo, now, digest)
+		}
+	case req.Method == http.MethodGet && resp.StatusCode == http.StatusOK:
+		err = rah.StatusManager.RecordPull(repo, now, reference)
+	case req.Method == http.MethodHead && resp.StatusCode == http.StatusOK && isTag:
+		err = rah.StatusManager.RecordTagPull(repo, reference, digest, now)
+	case req.Method == http.MethodPut && resp.StatusCode == http.StatusCreated && digest != "":
+		err = rah.StatusManager.RecordPush(repo, digest, now)
+	}
 	if err != nil {
-		log.Printf("[ERROR at RegistryApiHandler.StatusHandler]: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		log.Printf("[ERROR at RegistryApiHandler.trackActivity]: %v", err)
 	}
-	healthUrl.Path = path.Join(rah.ApiUrl.Path, "/v2/")
+	return nil
+}
 
-	resp, err := http.Get(healthUrl.String())
-	rah.StatusManager.Status.IsAlive = err == nil && resp.StatusCode == 200
-	res, err := json.Marshal(rah.StatusManager.Status)
-	if err != nil {
-		log.Printf("[ERROR at RegistryApiHandler.StatusHandler]: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+// Ping checks that the registry API answers and records the result in the status
+func (rah *RegistryApiHandler) Ping() bool {
+	client := &http.Client{Timeout: PingTimeout}
+	resp, err := client.Get(rah.Client.Url("/v2/").String())
+	if err == nil {
+		_ = resp.Body.Close()
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(res)
+	isAlive := err == nil && resp.StatusCode == 200
+	rah.StatusManager.SetIsAlive(isAlive)
+	return isAlive
 }
 
-func (rah *RegistryApiHandler) ManifestSummaryHeadHandler(w http.ResponseWriter, r *http.Request) {
-	manifestUrl := *rah.ApiUrl
-	manifestPath := strings.TrimSuffix(r.URL.Path, "/summary")
-	manifestUrl.Path = path.Join(manifestUrl.Path, manifestPath)
+func (rah *RegistryApiHandler) StatusHandler(w http.ResponseWriter, _ *http.Request) {
+	rah.Ping()
+	snapshot := rah.StatusManager.Snapshot()
+	http_response.WriteJson(w, &snapshot)
+}
 
+// headDigest resolves the reference with a HEAD request, upstream refusals are returned as upstreamError
+func (rah *RegistryApiHandler) headDigest(repo, reference string) (string, error) {
 	resChan := make(chan manifest.Result)
-	go manifest.HeadManifest(manifestUrl.String(), resChan)
+	go manifest.HeadManifest(rah.Client.ManifestUrl(repo, reference).String(), resChan)
 	res := <-resChan
 	close(resChan)
 
 	if res.Err != nil {
-		log.Printf("[ERROR at RegistryApiHandler.ManifestSummaryHeadHandler]: %v", res.Err)
-		http.Error(w, res.Err.Error(), http.StatusInternalServerError)
-		return
+		return "", res.Err
 	}
 	if res.ApiResp.StatusCode != 200 {
-		http.Error(w, res.ApiResp.Status, res.ApiResp.StatusCode)
+		return "", &upstreamError{resp: res.ApiResp}
+	}
+	return res.ApiResp.Header.Get("Docker-Content-Digest"), nil
+}
+
+func (rah *RegistryApiHandler) ManifestSummaryHeadHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	digest, err := rah.headDigest(vars["repo"], vars["tag"])
+	if err != nil {
+		if !isUpstreamError(err) {
+			log.Printf("[ERROR at RegistryApiHandler.ManifestSummaryHeadHandler]: %v", err)
+		}
+		writeError(w, err)
+		return
 	}
-	digest := res.ApiResp.Header.Get("Docker-Content-Digest")
 	w.Header().Set("Docker-Content-Digest", digest)
+	if notModified(w, r, entityTag(digest, rah.referrers(vars["repo"], digest))) {
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+// ManifestSummaryHandler revalidates the reference with HEAD, summaries are served from the digest cache
 func (rah *RegistryApiHandler) ManifestSummaryHandler(w http.ResponseWriter, r *http.Request) {
-	manifestUrl := *rah.ApiUrl
-	manifestPath := strings.TrimSuffix(r.URL.Path, "/summary")
-	manifestUrl.Path = path.Join(manifestUrl.Path, manifestPath)
-
-	v1chan := make(chan manifest.Result)
-	go manifest.GetV1Manifest(manifestUrl.String(), v1chan)
-	v2chan := make(chan manifest.Result)
-	go manifest.GetV2Manifest(manifestUrl.String(), v2chan)
-	var results [2]manifest.Result
-	results[0] = <-v1chan
-	results[1] = <-v2chan
-	close(v1chan)
-	close(v2chan)
-	for _, res := range results {
-		if res.Err == manifest.ErrApiStatusCode && res.ApiResp != nil {
-			http.Error(w, res.ApiResp.Status, res.ApiResp.StatusCode)
+	vars := mux.Vars(r)
+	repo, reference := vars["repo"], vars["tag"]
+	digest, err := rah.headDigest(repo, reference)
+	var referrers []manifest.Referrer
+	if err == nil {
+		w.Header().Set("Docker-Content-Digest", digest)
+		referrers = rah.referrers(repo, digest)
+		if notModified(w, r, entityTag(digest, referrers)) {
 			return
 		}
-		if res.Err != nil {
-			log.Printf("[ERROR at RegistryApiHandler.ManifestSummaryHandler]: %v", res.Err)
-			http.Error(w, res.Err.Error(), http.StatusInternalServerError)
-			return
+	}
+	var manifestSummary manifest.Summary
+	if err == nil {
+		manifestSummary, err = rah.summaryByDigest(repo, reference, digest)
+		manifestSummary.Referrers = referrers
+	}
+	if err != nil {
+		if !isUpstreamError(err) {
+			log.Printf("[ERROR at RegistryApiHandler.ManifestSummaryHandler]: %v", err)
 		}
+		writeError(w, err)
+		return
 	}
-	v1Manifest, v2Manifest := results[0].V1Manifest, results[1].V2Manifest
-	v2ApiResp := results[1].ApiResp
 
-	imageSize := v2Manifest.Config.Size
-	for _, layer := range v2Manifest.Layers {
-		imageSize += layer.Size
-	}
-	creationTime := time.Unix(0, 0).Format(time.RFC3339)
-	if len(v1Manifest.History) != 0 {
-		value := gjson.Get(v1Manifest.History[0].V1Compatibility, "created")
-		creationTime = value.Str
+	http_response.WriteJson(w, &manifestSummary)
+}
+
+func (rah *RegistryApiHandler) TagStatsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	repo, tag := vars["repo"], vars["tag"]
+	_, err := rah.Client.HeadManifest(repo, tag)
+	if errors.Is(err, registry_client.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
 	}
-	digest := v2ApiResp.Header.Get("Docker-Content-Digest")
-	manifestSummary := manifest.Summary{
-		Name:          v1Manifest.Name,
-		Tag:           v1Manifest.Tag,
-		Architecture:  v1Manifest.Architecture,
-		Created:       creationTime,
-		Size:          imageSize,
-		ContentDigest: digest,
+	if err != nil {
+		log.Printf("[ERROR at RegistryApiHandler.TagStatsHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
 	}
-
-	res, err := json.Marshal(&manifestSummary)
+	stats, err := rah.StatusManager.GetPullStats(repo, tag)
 	if err != nil {
-		log.Printf("[ERROR at RegistryApiHandler.ManifestSummaryHandler]: %v", err)
+		log.Printf("[ERROR at RegistryApiHandler.TagStatsHandler]: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write(res)
+	if stats == nil {
+		stats = &status.PullStats{Name: repo, Reference: tag}
+	}
+	http_response.WriteJson(w, stats)
 }
@@ -0,0 +1,97 @@
+package registry_api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"registry-cleaner-agent/internal/pkg/status"
+	"strings"
+	"testing"
+)
+
+var (
+	testDigest      = "sha256:" + strings.Repeat("a", 64)
+	testOtherDigest = "sha256:" + strings.Repeat("b", 64)
+)
+
+type testResponse struct {
+	method string
+	path   string
+	status int
+	digest string
+}
+
+func TestTrackActivity(t *testing.T) {
+	type want struct {
+		reference string
+		pulls     int64
+		digest    string
+		pushed    bool
+	}
+	tests := []struct {
+		name      string
+		responses []testResponse
+		want      []want
+	}{
+		{"tag resolved with HEAD, manifest fetched by digest", []testResponse{
+			{"HEAD", "/v2/team/api/manifests/latest", http.StatusOK, testDigest},
+			{"GET", "/v2/team/api/manifests/" + testDigest, http.StatusOK, testDigest},
+		}, []want{{"latest", 1, testDigest, false}, {testDigest, 1, "", false}}},
+		{"manifest fetched by tag", []testResponse{
+			{"GET", "/v2/team/api/manifests/latest", http.StatusOK, testDigest},
+		}, []want{{"latest", 1, testDigest, false}, {testDigest, 1, "", false}}},
+		{"tag moved", []testResponse{
+			{"HEAD", "/v2/team/api/manifests/latest", http.StatusOK, testDigest},
+			{"HEAD", "/v2/team/api/manifests/latest", http.StatusOK, testOtherDigest},
+		}, []want{{"latest", 2, testOtherDigest, false}}},
+		{"digest checked with HEAD", []testResponse{
+			{"HEAD", "/v2/team/api/manifests/" + testDigest, http.StatusOK, testDigest},
+		}, []want{{testDigest, 0, "", false}}},
+		{"unknown tag", []testResponse{
+			{"HEAD", "/v2/team/api/manifests/latest", http.StatusNotFound, ""},
+			{"GET", "/v2/team/api/manifests/latest", http.StatusNotFound, ""},
+		}, []want{{"latest", 0, "", false}}},
+		{"push", []testResponse{
+			{"PUT", "/v2/team/api/manifests/latest", http.StatusCreated, testDigest},
+		}, []want{{testDigest, 0, "", true}, {"latest", 0, "", false}}},
+		{"blob requests", []testResponse{
+			{"GET", "/v2/team/api/blobs/" + testDigest, http.StatusOK, testDigest},
+		}, []want{{testDigest, 0, "", false}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stm, err := status.InitStatusManager(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = stm.Shutdown() })
+			rah := &RegistryApiHandler{StatusManager: stm}
+			for _, tr := range tt.responses {
+				resp := &http.Response{
+					StatusCode: tr.status,
+					Header:     http.Header{},
+					Request:    httptest.NewRequest(tr.method, tr.path, nil),
+				}
+				if tr.digest != "" {
+					resp.Header.Set(registry_client.HeaderContentDigest, tr.digest)
+				}
+				if err = rah.trackActivity(resp); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, w := range tt.want {
+				stats, err := stm.GetPullStats("team/api", w.reference)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stats == nil {
+					stats = &status.PullStats{}
+				}
+				if stats.PullCount != w.pulls || stats.Digest != w.digest || (stats.LastPushedAt != "") != w.pushed {
+					t.Errorf("%s: pulls %d, digest %q, pushed at %q; want %d, %q, pushed %v", w.reference,
+						stats.PullCount, stats.Digest, stats.LastPushedAt, w.pulls, w.digest, w.pushed)
+				}
+			}
+		})
+	}
+}
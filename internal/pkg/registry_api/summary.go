@@ -0,0 +1,137 @@
+package registry_api
+
+import (
+	"errors"
+	"github.com/docker/distribution"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+)
+
+// upstreamError carries a non-successful registry response to the agent client
+type upstreamError struct {
+	resp *http.Response
+}
+
+func (e *upstreamError) Error() string {
+	return e.resp.Status
+}
+
+func resultError(result manifest.Result) error {
+	if result.Err == manifest.ErrApiStatusCode && result.ApiResp != nil {
+		return &upstreamError{resp: result.ApiResp}
+	}
+	return result.Err
+}
+
+// isUpstreamError tells refusals of the registry, not worth logging, from failures of the agent
+// isNotFound tells whether the registry does not know the manifest, e.g. it was deleted meanwhile
+func isNotFound(err error) bool {
+	var upstreamErr *upstreamError
+	if errors.As(err, &upstreamErr) {
+		return upstreamErr.resp.StatusCode == http.StatusNotFound
+	}
+	return errors.Is(err, registry_client.ErrNotFound)
+}
+
+func isUpstreamError(err error) bool {
+	var upstreamErr *upstreamError
+	return errors.As(err, &upstreamErr) || errors.Is(err, registry_client.ErrNotFound)
+}
+
+// writeError replies with the upstream status when the registry refused the request
+func writeError(w http.ResponseWriter, err error) {
+	var upstreamErr *upstreamError
+	switch {
+	case errors.As(err, &upstreamErr):
+		http.Error(w, upstreamErr.resp.Status, upstreamErr.resp.StatusCode)
+	case errors.Is(err, registry_client.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// getConfig fetches the image config blob, blobs are immutable so they are cached by digest
+func (rah *RegistryApiHandler) getConfig(repo string, descriptor distribution.Descriptor) (*v1.Image, error) {
+	digest := descriptor.Digest.String()
+	if config, ok := rah.configCache.Get(digest); ok {
+		return config.(*v1.Image), nil
+	}
+	configChan := make(chan manifest.ConfigResult)
+	go manifest.GetImageConfig(rah.Client.Url("/v2", repo, "blobs", digest).String(), configChan)
+	configResult := <-configChan
+	close(configChan)
+	if configResult.Err != nil {
+		return nil, configResult.Err
+	}
+	rah.configCache.Add(digest, configResult.Config)
+	return configResult.Config, nil
+}
+
+// imageSummary describes a single-platform image using its manifest and config blob
+func (rah *RegistryApiHandler) imageSummary(repo, tag, digest string, m distribution.Manifest) (manifest.Summary, error) {
+	configDescriptor, ok := manifest.ConfigDescriptor(m)
+	if !ok {
+		return manifest.Summarize(repo, tag, digest, m, nil), nil
+	}
+	config, err := rah.getConfig(repo, configDescriptor)
+	if err != nil {
+		return manifest.Summary{}, err
+	}
+	return manifest.Summarize(repo, tag, digest, m, config), nil
+}
+
+type childResult struct {
+	summary manifest.Summary
+	blobs   []distribution.Descriptor
+	err     error
+}
+
+// listSummary fetches every child manifest of the list concurrently
+func (rah *RegistryApiHandler) listSummary(repo, tag, digest string, list distribution.Manifest) (manifest.Summary, error) {
+	descriptors := manifest.ListDescriptors(list)
+	results := make([]chan childResult, len(descriptors))
+	for i, descriptor := range descriptors {
+		results[i] = make(chan childResult, 1)
+		go func(childDigest string, res chan<- childResult) {
+			child, _, err := rah.getManifest(repo, childDigest)
+			if err != nil {
+				res <- childResult{err: err}
+				return
+			}
+			summary, err := rah.imageSummary(repo, "", childDigest, child)
+			res <- childResult{summary: summary, blobs: child.References(), err: err}
+		}(descriptor.Digest.String(), results[i])
+	}
+	children := make([]manifest.Summary, len(descriptors))
+	blobs := make(map[string]int64)
+	var err error
+	for i := range results {
+		child := <-results[i]
+		if child.err != nil {
+			err = child.err
+			continue
+		}
+		children[i] = child.summary
+		for _, blob := range child.blobs {
+			blobs[blob.Digest.String()] = blob.Size
+		}
+	}
+	if err != nil {
+		return manifest.Summary{}, err
+	}
+	return manifest.SummarizeList(repo, tag, digest, list, children, blobs), nil
+}
+
+func (rah *RegistryApiHandler) buildSummary(repo, tag string) (manifest.Summary, error) {
+	m, digest, err := rah.getManifest(repo, tag)
+	if err != nil {
+		return manifest.Summary{}, err
+	}
+	if manifest.IsList(m) {
+		return rah.listSummary(repo, tag, digest, m)
+	}
+	return rah.imageSummary(repo, tag, digest, m)
+}
@@ -0,0 +1,92 @@
+package registry_api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/docker/distribution"
+	"io"
+	"log"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"strings"
+)
+
+// cachedSummary looks the digest up in memory, then in the status storage when persistence is enabled
+func (rah *RegistryApiHandler) cachedSummary(digest string) (manifest.Summary, bool) {
+	if cached, ok := rah.summaryCache.Get(digest); ok {
+		return cached.(manifest.Summary), true
+	}
+	if !rah.PersistSummaries {
+		return manifest.Summary{}, false
+	}
+	val, err := rah.StatusManager.GetCachedSummary(digest)
+	if err != nil || val == nil {
+		if err != nil {
+			log.Printf("[ERROR at RegistryApiHandler.cachedSummary]: %v", err)
+		}
+		return manifest.Summary{}, false
+	}
+	summary := manifest.Summary{}
+	if err = summary.UnmarshalJSON(val); err != nil {
+		log.Printf("[ERROR at RegistryApiHandler.cachedSummary]: %v", err)
+		return manifest.Summary{}, false
+	}
+	rah.summaryCache.Add(digest, summary)
+	return summary, true
+}
+
+func (rah *RegistryApiHandler) storeSummary(digest string, summary manifest.Summary) {
+	rah.summaryCache.Add(digest, summary)
+	if !rah.PersistSummaries {
+		return
+	}
+	val, err := summary.MarshalJSON()
+	if err == nil {
+		err = rah.StatusManager.SetCachedSummary(digest, val)
+	}
+	if err != nil {
+		log.Printf("[ERROR at RegistryApiHandler.storeSummary]: %v", err)
+	}
+}
+
+// cachedManifest returns a parsed manifest for digest references, tags are never cached;
+// entries are kept per repository so a digest is never served from a repository lacking it
+func (rah *RegistryApiHandler) cachedManifest(repo, reference string) (distribution.Manifest, bool) {
+	cached, ok := rah.manifestCache.Get(repo + "@" + reference)
+	if !ok {
+		return nil, false
+	}
+	return cached.(distribution.Manifest), true
+}
+
+func (rah *RegistryApiHandler) referrers(repo, digest string) []manifest.Referrer {
+	if rah.Referrers == nil {
+		return nil
+	}
+	return rah.Referrers.Referrers(repo, digest)
+}
+
+// entityTag is the content digest, extended with a hash of referrers since they change independently
+func entityTag(digest string, referrers []manifest.Referrer) string {
+	if len(referrers) == 0 {
+		return `"` + digest + `"`
+	}
+	h := sha256.New()
+	for _, referrer := range referrers {
+		_, _ = io.WriteString(h, referrer.Digest+referrer.Tag+"\n")
+	}
+	return `"` + digest + "+" + hex.EncodeToString(h.Sum(nil))[:12] + `"`
+}
+
+// notModified sets the ETag and replies 304 when the client already holds it
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package registry_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"golang.org/x/sync/semaphore"
+	"log"
+	"net/http"
+	"net/url"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// TagSummaryConcurrency bounds upstream requests of a single tags summary call
+	TagSummaryConcurrency = 8
+	DefaultTagsPageSize   = 100
+	MaxTagsPageSize       = 1000
+)
+
+// summaryByDigest returns a summary cached by content digest, building it on miss
+func (rah *RegistryApiHandler) summaryByDigest(repo, tag, digest string) (manifest.Summary, error) {
+	if summary, ok := rah.cachedSummary(digest); ok {
+		summary.Name, summary.Tag = repo, tag
+		return summary, nil
+	}
+	summary, err := rah.buildSummary(repo, digest)
+	if err != nil {
+		return manifest.Summary{}, err
+	}
+	rah.storeSummary(digest, summary)
+	summary.Tag = tag
+	return summary, nil
+}
+
+func (rah *RegistryApiHandler) tagSummary(repo, tag string) (manifest.Summary, error) {
+	digest, err := rah.Client.HeadManifest(repo, tag)
+	if err != nil {
+		return manifest.Summary{}, err
+	}
+	summary, err := rah.summaryByDigest(repo, tag, digest)
+	summary.Referrers = rah.referrers(repo, digest)
+	return summary, err
+}
+
+// tagSummaries builds summaries concurrently, tags removed meanwhile are skipped; tags failing otherwise,
+// e.g. schema1 manifests, are listed with the error so one tag does not fail the whole listing
+func (rah *RegistryApiHandler) tagSummaries(ctx context.Context, repo string, tags []string) ([]manifest.Summary, error) {
+	sem := semaphore.NewWeighted(TagSummaryConcurrency)
+	summaries := make([]manifest.Summary, len(tags))
+	errs := make([]error, len(tags))
+	for i, tag := range tags {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		go func(i int, tag string) {
+			defer sem.Release(1)
+			summaries[i], errs[i] = rah.tagSummary(repo, tag)
+		}(i, tag)
+	}
+	if err := sem.Acquire(ctx, TagSummaryConcurrency); err != nil {
+		return nil, err
+	}
+	res := make([]manifest.Summary, 0, len(tags))
+	for i := range tags {
+		if isNotFound(errs[i]) {
+			continue
+		}
+		if errs[i] != nil {
+			if !isUpstreamError(errs[i]) {
+				log.Printf("[ERROR at RegistryApiHandler.tagSummaries]: tag %s: %v", tags[i], errs[i])
+			}
+			summaries[i] = manifest.Summary{Name: repo, Tag: tags[i], Error: errs[i].Error()}
+		}
+		res = append(res, summaries[i])
+	}
+	return res, nil
+}
+
+func createdTime(summary manifest.Summary) time.Time {
+	created, err := time.Parse(time.RFC3339Nano, summary.Created)
+	if err != nil {
+		return time.Unix(0, 0)
+	}
+	return created
+}
+
+var summaryOrders = map[string]func(a, b manifest.Summary) bool{
+	"name": func(a, b manifest.Summary) bool {
+		return a.Tag < b.Tag
+	},
+	"created": func(a, b manifest.Summary) bool {
+		return createdTime(a).Before(createdTime(b))
+	},
+	"size": func(a, b manifest.Summary) bool {
+		return a.Size < b.Size
+	},
+}
+
+// pageBounds returns bounds of up to n keys following the "last" key, and the cursor of the next page;
+// an unknown "last" key, e.g. a tag deleted meanwhile, yields an empty page
+func pageBounds(keys []string, last string, n int) (start, end int, next string) {
+	if last != "" {
+		start = len(keys)
+		for i, key := range keys {
+			if key == last {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end = start + n
+	if end >= len(keys) {
+		return start, len(keys), ""
+	}
+	return start, end, keys[end-1]
+}
+
+// TagsSummaryHandler lists summaries of repository tags, query parameters:
+// sort=name|created|size, order=asc|desc, n and last for pagination
+func (rah *RegistryApiHandler) TagsSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+	query := r.URL.Query()
+	sortBy := query.Get("sort")
+	if sortBy == "" {
+		sortBy = "name"
+	}
+	less, ok := summaryOrders[sortBy]
+	if !ok {
+		http.Error(w, "sort must be one of: name, created, size", http.StatusBadRequest)
+		return
+	}
+	desc := query.Get("order") == "desc"
+	n := DefaultTagsPageSize
+	if value := query.Get("n"); value != "" {
+		var err error
+		if n, err = strconv.Atoi(value); err != nil || n <= 0 {
+			http.Error(w, "invalid page size", http.StatusBadRequest)
+			return
+		}
+		if n > MaxTagsPageSize {
+			n = MaxTagsPageSize
+		}
+	}
+
+	tags, err := rah.Client.Tags(repo)
+	if errors.Is(err, registry_client.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR at RegistryApiHandler.TagsSummaryHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	var summaries []manifest.Summary
+	var next string
+	if sortBy == "name" {
+		// Names are known upfront, so only the requested page is summarized
+		sort.Strings(tags)
+		if desc {
+			sort.Sort(sort.Reverse(sort.StringSlice(tags)))
+		}
+		var start, end int
+		start, end, next = pageBounds(tags, query.Get("last"), n)
+		summaries, err = rah.tagSummaries(r.Context(), repo, tags[start:end])
+	} else {
+		summaries, err = rah.tagSummaries(r.Context(), repo, tags)
+		if err == nil {
+			sort.SliceStable(summaries, func(i, j int) bool {
+				if desc {
+					return less(summaries[j], summaries[i])
+				}
+				return less(summaries[i], summaries[j])
+			})
+			sorted := make([]string, len(summaries))
+			for i := range summaries {
+				sorted[i] = summaries[i].Tag
+			}
+			var start, end int
+			start, end, next = pageBounds(sorted, query.Get("last"), n)
+			summaries = summaries[start:end]
+		}
+	}
+	if err != nil {
+		if !isUpstreamError(err) {
+			log.Printf("[ERROR at RegistryApiHandler.TagsSummaryHandler]: %v", err)
+		}
+		writeError(w, err)
+		return
+	}
+
+	if next != "" {
+		nextQuery := url.Values{}
+		for key, values := range query {
+			nextQuery[key] = values
+		}
+		nextQuery.Set("n", strconv.Itoa(n))
+		nextQuery.Set("last", next)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, nextQuery.Encode()))
+	}
+	http_response.WriteJson(w, &manifest.TagSummaries{Name: repo, Tags: summaries})
+}
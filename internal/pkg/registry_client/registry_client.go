@@ -0,0 +1,368 @@
+package registry_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"strings"
+)
+
+// Client performs agent-initiated calls to the upstream Registry API
+type Client struct {
+	ApiUrl *url.URL
+	http   *http.Client
+}
+
+const (
+	HeaderContentDigest = "Docker-Content-Digest"
+	catalogPageSize     = 1000
+)
+
+var (
+	ErrNotFound      = errors.New("registry API: not found")
+	ErrDenied        = errors.New("registry API: operation denied")
+	ErrUnexpectedApi = errors.New("registry API returned unexpected status")
+)
+
+func New(apiUrl *url.URL) *Client {
+	return &Client{
+		ApiUrl: apiUrl,
+		http:   &http.Client{},
+	}
+}
+
+// Url builds an upstream URL for the given path elements
+func (c *Client) Url(elem ...string) *url.URL {
+	u := *c.ApiUrl
+	u.Path = path.Join(append([]string{u.Path}, elem...)...)
+	return &u
+}
+
+func (c *Client) ManifestUrl(repo, reference string) *url.URL {
+	return c.Url("/v2", repo, "manifests", reference)
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.http.Do(req)
+}
+
+// StatusError maps upstream status codes to client errors
+func StatusError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusMethodNotAllowed:
+		return fmt.Errorf("%w: %s", ErrDenied, resp.Status)
+	}
+	return fmt.Errorf("%w: %s", ErrUnexpectedApi, resp.Status)
+}
+
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(ioutil.Discard, body)
+	_ = body.Close()
+}
+
+func (c *Client) getJson(u *url.URL, v interface{}) (*http.Response, error) {
+	resp, err := c.http.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return resp, StatusError(resp)
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// nextPage extracts the "last" parameter from a RFC5988 Link header
+func nextPage(resp *http.Response) (string, bool) {
+	link := resp.Header.Get("Link")
+	start, end := strings.Index(link, "<"), strings.Index(link, ">")
+	if start < 0 || end < start {
+		return "", false
+	}
+	next, err := url.Parse(link[start+1 : end])
+	if err != nil {
+		return "", false
+	}
+	last := next.Query().Get("last")
+	return last, last != ""
+}
+
+// Catalog lists all repositories following the registry pagination
+func (c *Client) Catalog() ([]string, error) {
+	var repositories []string
+	last := ""
+	for {
+		u := c.Url("/v2/_catalog")
+		q := u.Query()
+		q.Set("n", fmt.Sprint(catalogPageSize))
+		if last != "" {
+			q.Set("last", last)
+		}
+		u.RawQuery = q.Encode()
+		var page struct {
+			Repositories []string `json:"repositories"`
+		}
+		resp, err := c.getJson(u, &page)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, page.Repositories...)
+		var ok bool
+		if last, ok = nextPage(resp); !ok {
+			return repositories, nil
+		}
+	}
+}
+
+// Tags lists all tags of the repository; unknown repository yields ErrNotFound
+func (c *Client) Tags(repo string) ([]string, error) {
+	var tags []string
+	last := ""
+	for {
+		u := c.Url("/v2", repo, "tags/list")
+		if last != "" {
+			q := u.Query()
+			q.Set("last", last)
+			u.RawQuery = q.Encode()
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		resp, err := c.getJson(u, &page)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, page.Tags...)
+		var ok bool
+		if last, ok = nextPage(resp); !ok {
+			return tags, nil
+		}
+	}
+}
+
+// HeadManifest resolves a manifest reference to its content digest
+func (c *Client) HeadManifest(repo, reference string, accept ...string) (string, error) {
+	req, err := http.NewRequest(http.MethodHead, c.ManifestUrl(repo, reference).String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if len(accept) == 0 {
+		accept = manifest.AcceptedMediaTypes
+	}
+	for _, mediaType := range accept {
+		req.Header.Add("Accept", mediaType)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", StatusError(resp)
+	}
+	return resp.Header.Get(HeaderContentDigest), nil
+}
+
+// DeleteManifest removes the manifest revision; the registry drops tags pointing at it
+func (c *Client) DeleteManifest(repo, digest string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.ManifestUrl(repo, digest).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return StatusError(resp)
+	}
+	return nil
+}
+
+// RawManifest keeps the manifest bytes as stored, pushing them elsewhere preserves the digest
+type RawManifest struct {
+	Data      []byte
+	MediaType string
+	Digest    string
+}
+
+// GetManifest fetches the manifest negotiating any supported media type
+func (c *Client) GetManifest(repo, reference string) (*RawManifest, error) {
+	req, err := http.NewRequest(http.MethodGet, c.ManifestUrl(repo, reference).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, mediaType := range manifest.AcceptedMediaTypes {
+		req.Header.Add("Accept", mediaType)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, StatusError(resp)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	mediaType := manifest.ContentMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "" || mediaType == "application/json" {
+		mediaType = manifest.DetectMediaType(data)
+	}
+	return &RawManifest{Data: data, MediaType: mediaType, Digest: resp.Header.Get(HeaderContentDigest)}, nil
+}
+
+// PutManifest stores the manifest under a tag or its digest and returns the digest computed by the registry
+func (c *Client) PutManifest(repo, reference string, m *RawManifest) (string, error) {
+	req, err := http.NewRequest(http.MethodPut, c.ManifestUrl(repo, reference).String(), bytes.NewReader(m.Data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", m.MediaType)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return "", StatusError(resp)
+	}
+	return resp.Header.Get(HeaderContentDigest), nil
+}
+
+// BlobExists reports whether the blob is linked to the repository
+func (c *Client) BlobExists(repo, digest string) (bool, error) {
+	resp, err := c.http.Head(c.Url("/v2", repo, "blobs", digest).String())
+	if err != nil {
+		return false, err
+	}
+	defer drainAndClose(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, StatusError(resp)
+}
+
+// uploadLocation resolves the Location header of an upload session, registries may return relative URLs
+func (c *Client) uploadLocation(resp *http.Response) (*url.URL, error) {
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, err
+	}
+	return c.ApiUrl.ResolveReference(location), nil
+}
+
+func (c *Client) cancelUpload(location *url.URL) {
+	req, err := http.NewRequest(http.MethodDelete, location.String(), nil)
+	if err != nil {
+		return
+	}
+	if resp, err := c.http.Do(req); err == nil {
+		drainAndClose(resp.Body)
+	}
+}
+
+// MountBlob links the blob of another repository without transferring it; false is returned when
+// the registry did not mount it, e.g. cross-repository mounts are disabled
+func (c *Client) MountBlob(repo, from, digest string) (bool, error) {
+	// Upload URLs end with a slash, path.Join would strip it
+	u := c.Url("/v2", repo, "blobs/uploads")
+	u.Path += "/"
+	q := u.Query()
+	q.Set("mount", digest)
+	q.Set("from", from)
+	u.RawQuery = q.Encode()
+	resp, err := c.http.Post(u.String(), "", nil)
+	if err != nil {
+		return false, err
+	}
+	defer drainAndClose(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusAccepted:
+		// The registry opened a regular upload session instead
+		if location, err := c.uploadLocation(resp); err == nil {
+			c.cancelUpload(location)
+		}
+		return false, nil
+	}
+	return false, StatusError(resp)
+}
+
+// GetBlob opens the blob content, the caller closes the returned body
+func (c *Client) GetBlob(repo, digest string) (io.ReadCloser, int64, error) {
+	resp, err := c.http.Get(c.Url("/v2", repo, "blobs", digest).String())
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		drainAndClose(resp.Body)
+		return nil, 0, StatusError(resp)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// UploadBlob pushes the blob content in a monolithic upload, size may be -1 when unknown
+func (c *Client) UploadBlob(repo, digest string, size int64, content io.Reader) error {
+	u := c.Url("/v2", repo, "blobs/uploads")
+	u.Path += "/"
+	resp, err := c.http.Post(u.String(), "", nil)
+	if err != nil {
+		return err
+	}
+	drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusAccepted {
+		return StatusError(resp)
+	}
+	location, err := c.uploadLocation(resp)
+	if err != nil {
+		return err
+	}
+	q := location.Query()
+	q.Set("digest", digest)
+	location.RawQuery = q.Encode()
+	req, err := http.NewRequest(http.MethodPut, location.String(), content)
+	if err != nil {
+		c.cancelUpload(location)
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err = c.http.Do(req)
+	if err != nil {
+		c.cancelUpload(location)
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		c.cancelUpload(location)
+		return StatusError(resp)
+	}
+	return nil
+}
+
+// CopyBlob streams the blob from another repository in a monolithic upload
+func (c *Client) CopyBlob(repo, from, digest string) error {
+	content, size, err := c.GetBlob(from, digest)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(content)
+	return c.UploadBlob(repo, digest, size, content)
+}
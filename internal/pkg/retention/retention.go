@@ -0,0 +1,165 @@
+package retention
+
+import (
+	"errors"
+	"log"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"sort"
+	"time"
+)
+
+// Policy describes which images are eligible for deletion
+type Policy struct {
+	// MaxIdle deletes images neither pulled nor pushed for longer; zero disables the rule
+	MaxIdle time.Duration
+}
+
+//easyjson:json
+type Candidate struct {
+	Name         string   `json:"name"`
+	Digest       string   `json:"digest"`
+	Tags         []string `json:"tags"`
+	LastActivity string   `json:"lastActivity"`
+}
+
+//easyjson:json
+type Report struct {
+	Candidates []Candidate `json:"candidates"`
+	Deleted    int         `json:"deleted"`
+}
+
+var ErrDisabled = errors.New("retention policy is disabled")
+
+func (p Policy) Enabled() bool {
+	return p.MaxIdle > 0
+}
+
+// activity keeps the most recent pull or push of a digest and all of its tags.
+// Pulls by tag are recorded on the digest too, a tag pointing at a new digest does not pass its activity on
+type activity struct {
+	tags []string
+	last time.Time
+}
+
+// ListCandidates evaluates the policy against every tag of the registry without changing any state,
+// digests the agent has not seen yet are not candidates
+func (rh *Handler) ListCandidates() ([]Candidate, error) {
+	return rh.listCandidates(false)
+}
+
+// listCandidates starts aging unseen digests from now when mark is set
+func (rh *Handler) listCandidates(mark bool) ([]Candidate, error) {
+	if !rh.Policy.Enabled() {
+		return nil, ErrDisabled
+	}
+	now := time.Now()
+	repositories, err := rh.Client.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []Candidate
+	for _, repo := range repositories {
+		repoCandidates, err := rh.listRepoCandidates(repo, now, mark)
+		if err != nil {
+			log.Printf("[ERROR at retention.Handler.ListCandidates]: repository %s: %v", repo, err)
+			continue
+		}
+		candidates = append(candidates, repoCandidates...)
+	}
+	return candidates, nil
+}
+
+func (rh *Handler) listRepoCandidates(repo string, now time.Time, mark bool) ([]Candidate, error) {
+	tags, err := rh.Client.Tags(repo)
+	if errors.Is(err, registry_client.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	digests := make(map[string]*activity)
+	for _, tag := range tags {
+		// cosign signatures, attestations and SBOMs follow their subject, see untagged.ListUntagged
+		if _, _, ok := manifest.CosignSubject(tag); ok {
+			continue
+		}
+		digest, err := rh.Client.HeadManifest(repo, tag)
+		if errors.Is(err, registry_client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		act, ok := digests[digest]
+		if !ok {
+			act, err = rh.digestActivity(repo, digest, now, mark)
+			if err != nil {
+				return nil, err
+			}
+			digests[digest] = act
+		}
+		act.tags = append(act.tags, tag)
+	}
+	var candidates []Candidate
+	for digest, act := range digests {
+		if now.Sub(act.last) <= rh.Policy.MaxIdle {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			Name:         repo,
+			Digest:       digest,
+			Tags:         act.tags,
+			LastActivity: act.last.Format(time.RFC3339),
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].LastActivity < candidates[j].LastActivity
+	})
+	return candidates, nil
+}
+
+func (rh *Handler) digestActivity(repo, digest string, now time.Time, mark bool) (*activity, error) {
+	stats, err := rh.StatusManager.GetPullStats(repo, digest)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil && mark {
+		stats, err = rh.StatusManager.MarkSeen(repo, digest, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if stats == nil {
+		return &activity{last: now}, nil
+	}
+	return &activity{last: stats.LastActivity()}, nil
+}
+
+// Apply deletes manifests of every candidate, the blobs are left for the garbage collector
+func (rh *Handler) Apply() (*Report, error) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	candidates, err := rh.listCandidates(true)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Candidates: []Candidate{}}
+	for _, candidate := range candidates {
+		err = rh.Client.DeleteManifest(candidate.Name, candidate.Digest)
+		if err != nil {
+			log.Printf("[ERROR at retention.Handler.Apply]: %s@%s: %v", candidate.Name, candidate.Digest, err)
+			continue
+		}
+		log.Printf("[INFO at retention.Handler.Apply]: deleted %s@%s (tags %v, last activity %s)",
+			candidate.Name, candidate.Digest, candidate.Tags, candidate.LastActivity)
+		for _, reference := range append(candidate.Tags, candidate.Digest) {
+			if err = rh.StatusManager.DeletePullStats(candidate.Name, reference); err != nil {
+				log.Printf("[ERROR at retention.Handler.Apply]: %v", err)
+			}
+		}
+		report.Candidates = append(report.Candidates, candidate)
+		report.Deleted++
+	}
+	return report, nil
+}
@@ -0,0 +1,243 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package retention
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson41a64f0DecodeRegistryCleanerAgentInternalPkgRetention(in *jlexer.Lexer, out *Report) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "candidates":
+			if in.IsNull() {
+				in.Skip()
+				out.Candidates = nil
+			} else {
+				in.Delim('[')
+				if out.Candidates == nil {
+					if !in.IsDelim(']') {
+						out.Candidates = make([]Candidate, 0, 0)
+					} else {
+						out.Candidates = []Candidate{}
+					}
+				} else {
+					out.Candidates = (out.Candidates)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Candidate
+					(v1).UnmarshalEasyJSON(in)
+					out.Candidates = append(out.Candidates, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "deleted":
+			out.Deleted = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson41a64f0EncodeRegistryCleanerAgentInternalPkgRetention(out *jwriter.Writer, in Report) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"candidates\":"
+		out.RawString(prefix[1:])
+		if in.Candidates == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Candidates {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix)
+		out.Int(int(in.Deleted))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Report) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson41a64f0EncodeRegistryCleanerAgentInternalPkgRetention(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Report) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson41a64f0EncodeRegistryCleanerAgentInternalPkgRetention(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Report) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson41a64f0DecodeRegistryCleanerAgentInternalPkgRetention(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Report) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson41a64f0DecodeRegistryCleanerAgentInternalPkgRetention(l, v)
+}
+func easyjson41a64f0DecodeRegistryCleanerAgentInternalPkgRetention1(in *jlexer.Lexer, out *Candidate) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Tags = append(out.Tags, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "lastActivity":
+			out.LastActivity = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson41a64f0EncodeRegistryCleanerAgentInternalPkgRetention1(out *jwriter.Writer, in Candidate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Tags {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"lastActivity\":"
+		out.RawString(prefix)
+		out.String(string(in.LastActivity))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Candidate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson41a64f0EncodeRegistryCleanerAgentInternalPkgRetention1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Candidate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson41a64f0EncodeRegistryCleanerAgentInternalPkgRetention1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Candidate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson41a64f0DecodeRegistryCleanerAgentInternalPkgRetention1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Candidate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson41a64f0DecodeRegistryCleanerAgentInternalPkgRetention1(l, v)
+}
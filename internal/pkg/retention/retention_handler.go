@@ -0,0 +1,95 @@
+package retention
+
+import (
+	"errors"
+	"github.com/robfig/cron"
+	"log"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"registry-cleaner-agent/internal/pkg/status"
+	"sync"
+)
+
+type Handler struct {
+	Client        *registry_client.Client
+	StatusManager *status.Manager
+	Policy        Policy
+	cron          *cron.Cron
+	mu            *sync.Mutex
+}
+
+func InitRetentionHandler(
+	client *registry_client.Client, stm *status.Manager, policy Policy) (*Handler, error) {
+	if client == nil || stm == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		Client:        client,
+		StatusManager: stm,
+		Policy:        policy,
+		cron:          cron.New(),
+		mu:            &sync.Mutex{},
+	}, nil
+}
+
+// EnableCron schedules policy application; disabled policy or empty spec schedules nothing
+func (rh *Handler) EnableCron(spec string) error {
+	if spec == "" || !rh.Policy.Enabled() {
+		return nil
+	}
+	err := rh.cron.AddFunc(spec, rh.ApplyRetention)
+	if err != nil {
+		return err
+	}
+	rh.cron.Start()
+	return nil
+}
+
+func (rh *Handler) DisableCron() {
+	rh.cron.Stop()
+	rh.cron = cron.New() // Removes entries
+}
+
+func (rh *Handler) ApplyRetention() {
+	report, err := rh.Apply()
+	if err != nil {
+		log.Printf("[ERROR at retention.Handler.ApplyRetention]: %v", err)
+		return
+	}
+	log.Printf("[INFO at retention.Handler.ApplyRetention]: %d manifests deleted", report.Deleted)
+}
+
+func writeReport(w http.ResponseWriter, report *Report) {
+	http_response.WriteJson(w, report)
+}
+
+func (rh *Handler) RetentionGetHandler(w http.ResponseWriter, _ *http.Request) {
+	candidates, err := rh.ListCandidates()
+	if errors.Is(err, ErrDisabled) {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if candidates == nil {
+		candidates = []Candidate{}
+	}
+	writeReport(w, &Report{Candidates: candidates})
+}
+
+func (rh *Handler) RetentionDeleteHandler(w http.ResponseWriter, _ *http.Request) {
+	report, err := rh.Apply()
+	if errors.Is(err, ErrDisabled) {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeReport(w, report)
+}
@@ -0,0 +1,239 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"registry-cleaner-agent/internal/pkg/status"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//easyjson:json
+type Results struct {
+	IndexedAt string               `json:"indexedAt"`
+	Results   []status.SearchEntry `json:"results"`
+}
+
+//easyjson:json
+type DigestTags struct {
+	Name      string   `json:"name"`
+	Digest    string   `json:"digest"`
+	IndexedAt string   `json:"indexedAt"`
+	Tags      []string `json:"tags"`
+}
+
+var ErrInvalidFilter = errors.New("invalid filter expression")
+
+// operators are matched in this order, so two-character operators win over their prefixes
+var operators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// Filter is a single "<field><operator><value>" expression, e.g. "label.team=payments" or "size>=1048576".
+// Fields are name, tag, digest, architecture, os, created, size and label.<key>;
+// "=" and "!=" accept "*" wildcards, ordering operators apply to created and size
+type Filter struct {
+	Field    string
+	Operator string
+	Value    string
+	pattern  *regexp.Regexp
+	created  time.Time
+	size     int64
+}
+
+func glob(value string) *regexp.Regexp {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(value), `\*`, ".*")
+	return regexp.MustCompile("^" + expr + "$")
+}
+
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", value)
+	}
+	return t, err
+}
+
+func ParseFilter(expr string) (*Filter, error) {
+	pos, op := -1, ""
+	for _, candidate := range operators {
+		i := strings.Index(expr, candidate)
+		if i > 0 && (pos == -1 || i < pos) {
+			pos, op = i, candidate
+		}
+	}
+	if pos == -1 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFilter, expr)
+	}
+	f := &Filter{Field: expr[:pos], Operator: op, Value: expr[pos+len(op):]}
+	ordering := op != "=" && op != "!="
+	var err error
+	switch {
+	case f.Field == "created":
+		f.created, err = parseTime(f.Value)
+	case f.Field == "size":
+		f.size, err = strconv.ParseInt(f.Value, 10, 64)
+	case ordering:
+		err = errors.New("ordering operators apply to created and size only")
+	case f.Field == "name", f.Field == "tag", f.Field == "digest", f.Field == "architecture", f.Field == "os",
+		strings.HasPrefix(f.Field, "label.") && len(f.Field) > len("label."):
+		f.pattern = glob(f.Value)
+	default:
+		err = errors.New("unknown field")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, expr, err)
+	}
+	return f, nil
+}
+
+func compare(cmp int, op string) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// matchAny checks comma separated values, e.g. architectures of a manifest list
+func (f *Filter) matchAny(values string) bool {
+	matched := false
+	for _, value := range strings.Split(values, ",") {
+		if f.pattern.MatchString(value) {
+			matched = true
+			break
+		}
+	}
+	return matched == (f.Operator == "=")
+}
+
+func (f *Filter) Match(entry *status.SearchEntry) bool {
+	switch f.Field {
+	case "name":
+		return f.matchAny(entry.Name)
+	case "tag":
+		return f.matchAny(entry.Tag)
+	case "digest":
+		return f.matchAny(entry.Digest)
+	case "architecture":
+		return f.matchAny(entry.Architecture)
+	case "os":
+		return f.matchAny(entry.OS)
+	case "created":
+		created, err := time.Parse(time.RFC3339Nano, entry.Created)
+		if err != nil {
+			return false
+		}
+		cmp := 0
+		if created.Before(f.created) {
+			cmp = -1
+		} else if created.After(f.created) {
+			cmp = 1
+		}
+		return compare(cmp, f.Operator)
+	case "size":
+		cmp := 0
+		if entry.Size < f.size {
+			cmp = -1
+		} else if entry.Size > f.size {
+			cmp = 1
+		}
+		return compare(cmp, f.Operator)
+	}
+	value, ok := entry.Labels[strings.TrimPrefix(f.Field, "label.")]
+	if !ok {
+		return f.Operator == "!="
+	}
+	return f.pattern.MatchString(value) == (f.Operator == "=")
+}
+
+// Build summarizes every tag of the registry storage
+func Build(fsa *fs_analyzer.Analyzer) ([]status.SearchEntry, error) {
+	repositories, err := fsa.ListRepositories()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]status.SearchEntry, 0)
+	for _, repo := range repositories {
+		tags, err := fsa.ListTags(repo)
+		if err != nil {
+			return nil, err
+		}
+		for tag, digest := range tags {
+			entry := status.SearchEntry{Name: repo, Tag: tag, Digest: digest}
+			// Tags are indexed even without a summary, digest lookups have to know every one of them
+			summary, err := manifest.SummarizeStored(repo, tag, digest, fsa.ReadBlob)
+			if err != nil {
+				log.Printf("[ERROR at search.Build]: %s:%s: %v", repo, tag, err)
+			} else {
+				entry.MediaType = summary.MediaType
+				entry.Architecture = summary.Architecture
+				entry.OS = summary.OS
+				entry.Created = summary.Created
+				entry.Size = summary.Size
+				entry.Labels = summary.Labels
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func sortEntries(entries []status.SearchEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Tag < entries[j].Tag
+	})
+}
+
+// repositoryEntries returns the range of entries sorted by repository which belongs to the repository
+func repositoryEntries(entries []status.SearchEntry, repo string) []status.SearchEntry {
+	start := sort.Search(len(entries), func(i int) bool { return entries[i].Name >= repo })
+	end := sort.Search(len(entries), func(i int) bool { return entries[i].Name > repo })
+	return entries[start:end]
+}
+
+// Search returns entries matching every filter, sorted by repository and tag
+func Search(entries []status.SearchEntry, filters []*Filter) []status.SearchEntry {
+	res := make([]status.SearchEntry, 0)
+	for i := range entries {
+		matched := true
+		for _, f := range filters {
+			if !f.Match(&entries[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			res = append(res, entries[i])
+		}
+	}
+	sortEntries(res)
+	return res
+}
+
+// TagsOf returns sorted tags of the repository entries pointing to the digest
+func TagsOf(entries []status.SearchEntry, digest string) []string {
+	tags := make([]string, 0)
+	for i := range entries {
+		if entries[i].Digest == digest {
+			tags = append(tags, entries[i].Tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
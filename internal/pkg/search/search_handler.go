@@ -0,0 +1,206 @@
+package search
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/robfig/cron"
+	"log"
+	"net/http"
+	"net/url"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/status"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Handler struct {
+	FSAnalyzer    *fs_analyzer.Analyzer
+	StatusManager *status.Manager
+	cron          *cron.Cron
+	mu            *sync.Mutex
+	// entries caches the stored index sorted by repository and tag, it is replaced on refresh
+	entries   []status.SearchEntry
+	indexedAt string
+	cacheMu   *sync.RWMutex
+}
+
+const (
+	DefaultResults = 100
+	MaxResults     = 1000
+)
+
+func InitSearchHandler(fsa *fs_analyzer.Analyzer, stm *status.Manager) (*Handler, error) {
+	if fsa == nil || stm == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		FSAnalyzer:    fsa,
+		StatusManager: stm,
+		cron:          cron.New(),
+		mu:            &sync.Mutex{},
+		cacheMu:       &sync.RWMutex{},
+	}, nil
+}
+
+// EnableCron schedules index refresh, the index is built right away if it was never stored
+func (sh *Handler) EnableCron(spec string) error {
+	err := sh.cron.AddFunc(spec, sh.Refresh)
+	if err != nil {
+		return err
+	}
+	sh.cron.Start()
+	_, indexedAt, err := sh.snapshot()
+	if err != nil {
+		return err
+	}
+	if indexedAt == "" {
+		go sh.Refresh()
+	}
+	return nil
+}
+
+func (sh *Handler) DisableCron() {
+	sh.cron.Stop()
+	sh.cron = cron.New() // Removes entries
+}
+
+func (sh *Handler) Refresh() {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	indexedAt := time.Now()
+	entries, err := Build(sh.FSAnalyzer)
+	if err != nil {
+		log.Printf("[ERROR at search.Handler.Refresh]: %v", err)
+		return
+	}
+	err = sh.StatusManager.ReplaceSearchEntries(entries, indexedAt)
+	if err != nil {
+		log.Printf("[ERROR at search.Handler.Refresh]: %v", err)
+		return
+	}
+	sh.setSnapshot(entries, indexedAt.Format(time.RFC3339), true)
+	log.Printf("[INFO at search.Handler.Refresh]: %d tags indexed", len(entries))
+	// Nothing else merges the status storage, which only grows with overwritten and deleted values
+	if err = sh.StatusManager.Storage.Merge(); err != nil {
+		log.Printf("[ERROR at search.Handler.Refresh]: %v", err)
+	}
+}
+
+// snapshot returns the cached index, it is read from the status storage once
+func (sh *Handler) snapshot() ([]status.SearchEntry, string, error) {
+	sh.cacheMu.RLock()
+	entries, indexedAt := sh.entries, sh.indexedAt
+	sh.cacheMu.RUnlock()
+	if entries != nil {
+		return entries, indexedAt, nil
+	}
+	entries, indexedAt, err := sh.StatusManager.ListSearchEntries()
+	if err != nil {
+		return nil, "", err
+	}
+	entries, indexedAt = sh.setSnapshot(entries, indexedAt, false)
+	return entries, indexedAt, nil
+}
+
+// setSnapshot caches the index; a stored index only fills an empty cache, so it never replaces a fresh one
+func (sh *Handler) setSnapshot(entries []status.SearchEntry, indexedAt string, fresh bool) ([]status.SearchEntry, string) {
+	sorted := make([]status.SearchEntry, len(entries))
+	copy(sorted, entries)
+	sortEntries(sorted)
+	sh.cacheMu.Lock()
+	defer sh.cacheMu.Unlock()
+	if fresh || sh.entries == nil {
+		sh.entries, sh.indexedAt = sorted, indexedAt
+	}
+	return sh.entries, sh.indexedAt
+}
+
+// SearchHandler applies every "filter" query parameter, "n" and "last" (<name>:<tag>) paginate the results
+func (sh *Handler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filters := make([]*Filter, 0, len(query["filter"]))
+	for _, expr := range query["filter"] {
+		f, err := ParseFilter(expr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filters = append(filters, f)
+	}
+	n := DefaultResults
+	if value := query.Get("n"); value != "" {
+		var err error
+		n, err = strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid number of results", http.StatusBadRequest)
+			return
+		}
+		if n > MaxResults {
+			n = MaxResults
+		}
+	}
+	var lastName, lastTag string
+	if last := query.Get("last"); last != "" {
+		i := strings.IndexByte(last, ':')
+		if i < 0 {
+			http.Error(w, "last must be given as <name>:<tag>", http.StatusBadRequest)
+			return
+		}
+		lastName, lastTag = last[:i], last[i+1:]
+	}
+	entries, indexedAt, err := sh.snapshot()
+	if err != nil {
+		log.Printf("[ERROR at search.Handler.SearchHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	results := Search(entries, filters)
+	start := sort.Search(len(results), func(i int) bool {
+		return results[i].Name > lastName || results[i].Name == lastName && results[i].Tag > lastTag
+	})
+	results = results[start:]
+	if len(results) > n {
+		results = results[:n]
+		next := url.Values{}
+		next["filter"] = query["filter"]
+		next.Set("n", strconv.Itoa(n))
+		next.Set("last", results[n-1].Name+":"+results[n-1].Tag)
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	}
+	http_response.WriteJson(w, &Results{IndexedAt: indexedAt, Results: results})
+}
+
+// DigestTagsHandler lists tags resolving to the manifest digest as of the last index refresh, the refresh time
+// is sent as Last-Modified; repositories and digests missing in the index, e.g. pushed since, are unknown
+func (sh *Handler) DigestTagsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	repo, digest := vars["repo"], vars["digest"]
+	entries, indexedAt, err := sh.snapshot()
+	if err != nil {
+		log.Printf("[ERROR at search.Handler.DigestTagsHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if t, err := time.Parse(time.RFC3339, indexedAt); err == nil {
+		w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+	}
+	repoEntries := repositoryEntries(entries, repo)
+	if len(repoEntries) == 0 {
+		agent_errors.WriteRegistryError(w, http.StatusNotFound, agent_errors.CodeNameUnknown,
+			"repository name not known to the search index", map[string]string{"name": repo, "indexedAt": indexedAt})
+		return
+	}
+	tags := TagsOf(repoEntries, digest)
+	if len(tags) == 0 {
+		agent_errors.WriteRegistryError(w, http.StatusNotFound, agent_errors.CodeManifestUnknown,
+			"manifest not known to the search index",
+			map[string]string{"name": repo, "digest": digest, "indexedAt": indexedAt})
+		return
+	}
+	http_response.WriteJson(w, &DigestTags{Name: repo, Digest: digest, IndexedAt: indexedAt, Tags: tags})
+}
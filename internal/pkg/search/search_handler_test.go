@@ -0,0 +1,83 @@
+package search
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/status"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDigestTagsHandler(t *testing.T) {
+	stm, err := status.InitStatusManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = stm.Shutdown() })
+	indexedAt := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	digest := "sha256:" + strings.Repeat("a", 64)
+	err = stm.ReplaceSearchEntries([]status.SearchEntry{
+		{Name: "team/api", Tag: "latest", Digest: digest},
+		{Name: "team/api", Tag: "1.0", Digest: digest},
+		{Name: "team/api", Tag: "0.9", Digest: "sha256:" + strings.Repeat("b", 64)},
+	}, indexedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sh, err := InitSearchHandler(fs_analyzer.NewFSAnalyzer(t.TempDir()), stm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		repo     string
+		digest   string
+		want     int
+		wantCode string
+		wantTags []string
+	}{
+		{"tagged digest", "team/api", digest, http.StatusOK, "", []string{"1.0", "latest"}},
+		{"unknown digest", "team/api", "sha256:" + strings.Repeat("c", 64), http.StatusNotFound,
+			agent_errors.CodeManifestUnknown, nil},
+		{"unknown repository", "team/web", digest, http.StatusNotFound, agent_errors.CodeNameUnknown, nil},
+		{"repository prefix", "team", digest, http.StatusNotFound, agent_errors.CodeNameUnknown, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mux.SetURLVars(httptest.NewRequest("GET", "/", nil), map[string]string{"repo": tt.repo, "digest": tt.digest})
+			w := httptest.NewRecorder()
+			sh.DigestTagsHandler(w, r)
+			if w.Code != tt.want {
+				t.Fatalf("status %d, want %d", w.Code, tt.want)
+			}
+			if got := w.Header().Get("Last-Modified"); got != indexedAt.Format(http.TimeFormat) {
+				t.Errorf("Last-Modified %q, want %q", got, indexedAt.Format(http.TimeFormat))
+			}
+			if tt.want != http.StatusOK {
+				errs := agent_errors.RegistryErrors{}
+				if err := json.Unmarshal(w.Body.Bytes(), &errs); err != nil {
+					t.Fatal(err)
+				}
+				if len(errs.Errors) != 1 || errs.Errors[0].Code != tt.wantCode {
+					t.Errorf("errors %v, want %s", errs.Errors, tt.wantCode)
+				}
+				return
+			}
+			res := DigestTags{}
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(res.Tags, ",") != strings.Join(tt.wantTags, ",") {
+				t.Errorf("tags %v, want %v", res.Tags, tt.wantTags)
+			}
+			if res.IndexedAt != indexedAt.Format(time.RFC3339) {
+				t.Errorf("indexedAt %q, want %q", res.IndexedAt, indexedAt.Format(time.RFC3339))
+			}
+		})
+	}
+}
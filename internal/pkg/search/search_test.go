@@ -0,0 +1,118 @@
+package search
+
+import (
+	"errors"
+	"registry-cleaner-agent/internal/pkg/status"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr     string
+		field    string
+		operator string
+		value    string
+		wantErr  bool
+	}{
+		{"label.team=payments", "label.team", "=", "payments", false},
+		{"architecture!=arm64", "architecture", "!=", "arm64", false},
+		{"name=team/*", "name", "=", "team/*", false},
+		{"created>=2021-01-01", "created", ">=", "2021-01-01", false},
+		{"created<2021-01-01T10:00:00Z", "created", "<", "2021-01-01T10:00:00Z", false},
+		{"size>1048576", "size", ">", "1048576", false},
+		{"size<=0", "size", "<=", "0", false},
+		{"label.url=http://a?b=c", "label.url", "=", "http://a?b=c", false},
+		{"tag=", "tag", "=", "", false},
+		{"=latest", "", "", "", true},
+		{"latest", "", "", "", true},
+		{"label.=x", "", "", "", true},
+		{"owner=me", "", "", "", true},
+		{"tag>1.0", "", "", "", true},
+		{"size>=1MB", "", "", "", true},
+		{"created>yesterday", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFilter) {
+					t.Fatalf("err = %v, want %v", err, ErrInvalidFilter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Field != tt.field || f.Operator != tt.operator || f.Value != tt.value {
+				t.Errorf("got %q %q %q, want %q %q %q", f.Field, f.Operator, f.Value, tt.field, tt.operator, tt.value)
+			}
+		})
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	entry := &status.SearchEntry{
+		Name:         "team/api",
+		Tag:          "1.0",
+		Digest:       "sha256:0123",
+		Architecture: "amd64,arm64",
+		OS:           "linux",
+		Created:      "2021-06-01T12:00:00.5Z",
+		Size:         1000,
+		Labels:       map[string]string{"team": "payments"},
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"name=team/*", true},
+		{"name=team", false},
+		{"tag!=1.*", false},
+		{"digest=sha256:*", true},
+		{"architecture=arm64", true},
+		{"architecture!=arm64", false},
+		{"architecture=ppc64le", false},
+		{"os=windows", false},
+		{"created>=2021-06-01", true},
+		{"created<2021-06-01T12:00:00Z", false},
+		{"created>2021-06-01T12:00:00Z", true},
+		{"size=1000", true},
+		{"size>1000", false},
+		{"size<=1000", true},
+		{"label.team=pay*", true},
+		{"label.team!=payments", false},
+		{"label.owner=*", false},
+		{"label.owner!=me", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Match(entry); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	entries := []status.SearchEntry{
+		{Name: "team/api", Tag: "1.0", Architecture: "amd64"},
+		{Name: "team/api", Tag: "2.0", Architecture: "arm64"},
+		{Name: "team/web", Tag: "1.0", Architecture: "arm64"},
+	}
+	var filters []*Filter
+	for _, expr := range []string{"name=team/*", "architecture=arm64"} {
+		f, err := ParseFilter(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filters = append(filters, f)
+	}
+	results := Search(entries, filters)
+	if len(results) != 2 || results[0].Tag != "2.0" || results[1].Name != "team/web" {
+		t.Errorf("got %v, want team/api:2.0 and team/web:1.0", results)
+	}
+}
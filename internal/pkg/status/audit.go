@@ -0,0 +1,82 @@
+package status
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+//easyjson:json
+type AuditEntry struct {
+	ID     string `json:"id"`
+	Time   string `json:"time"`
+	Action string `json:"action"`
+	// Client is the remote address of the request which caused the action
+	Client string `json:"client"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target"`
+	Digest string `json:"digest,omitempty"`
+	// PreviousDigest is the manifest the target tag pointed to before
+	PreviousDigest string `json:"previousDigest,omitempty"`
+	Result         string `json:"result"`
+	Manifests      int    `json:"manifests,omitempty"`
+	MountedBlobs   int    `json:"mountedBlobs,omitempty"`
+	CopiedBlobs    int    `json:"copiedBlobs,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+//easyjson:json
+type AuditLog struct {
+	Entries []AuditEntry `json:"entries"`
+}
+
+const auditPrefix = "audit/"
+
+// auditKey layout is audit/<id>, ids are zero padded so keys sort chronologically
+func auditKey(id string) []byte {
+	return []byte(auditPrefix + id)
+}
+
+// AddAuditEntry appends the entry to the audit log, ID and Time are assigned here
+func (m *Manager) AddAuditEntry(entry *AuditEntry) error {
+	now := time.Now()
+	entry.ID = m.nextID(now)
+	entry.Time = now.Format(time.RFC3339Nano)
+	val, err := entry.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return m.Storage.SetValue(auditKey(entry.ID), val)
+}
+
+// ListAuditEntries returns up to n entries recorded after the entry with id last, oldest first; n <= 0 means all
+func (m *Manager) ListAuditEntries(last string, n int) ([]AuditEntry, error) {
+	res := make([]AuditEntry, 0)
+	err := m.Storage.ScanValues([]byte(auditPrefix), func(key []byte, value []byte) error {
+		if strings.TrimPrefix(string(key), auditPrefix) <= last {
+			return nil
+		}
+		entry := AuditEntry{}
+		if err := entry.UnmarshalJSON(value); err != nil {
+			return err
+		}
+		res = append(res, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	if n > 0 && len(res) > n {
+		res = res[:n]
+	}
+	return res, nil
+}
+
+// PruneAuditEntries deletes entries recorded before the cutoff and the oldest entries beyond maxEntries,
+// a zero cutoff or maxEntries disables the respective limit
+func (m *Manager) PruneAuditEntries(before time.Time, maxEntries int) (int, error) {
+	return m.pruneEntries(auditPrefix, before, maxEntries)
+}
@@ -0,0 +1,267 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package status
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF2c44427DecodeRegistryCleanerAgentInternalPkgStatus(in *jlexer.Lexer, out *AuditLog) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "entries":
+			if in.IsNull() {
+				in.Skip()
+				out.Entries = nil
+			} else {
+				in.Delim('[')
+				if out.Entries == nil {
+					if !in.IsDelim(']') {
+						out.Entries = make([]AuditEntry, 0, 0)
+					} else {
+						out.Entries = []AuditEntry{}
+					}
+				} else {
+					out.Entries = (out.Entries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 AuditEntry
+					(v1).UnmarshalEasyJSON(in)
+					out.Entries = append(out.Entries, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeRegistryCleanerAgentInternalPkgStatus(out *jwriter.Writer, in AuditLog) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix[1:])
+		if in.Entries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Entries {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditLog) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeRegistryCleanerAgentInternalPkgStatus(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditLog) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeRegistryCleanerAgentInternalPkgStatus(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditLog) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeRegistryCleanerAgentInternalPkgStatus(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditLog) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeRegistryCleanerAgentInternalPkgStatus(l, v)
+}
+func easyjsonF2c44427DecodeRegistryCleanerAgentInternalPkgStatus1(in *jlexer.Lexer, out *AuditEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "time":
+			out.Time = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "client":
+			out.Client = string(in.String())
+		case "source":
+			out.Source = string(in.String())
+		case "target":
+			out.Target = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "previousDigest":
+			out.PreviousDigest = string(in.String())
+		case "result":
+			out.Result = string(in.String())
+		case "manifests":
+			out.Manifests = int(in.Int())
+		case "mountedBlobs":
+			out.MountedBlobs = int(in.Int())
+		case "copiedBlobs":
+			out.CopiedBlobs = int(in.Int())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeRegistryCleanerAgentInternalPkgStatus1(out *jwriter.Writer, in AuditEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.String(string(in.Time))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"client\":"
+		out.RawString(prefix)
+		out.String(string(in.Client))
+	}
+	if in.Source != "" {
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.String(string(in.Source))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
+	if in.Digest != "" {
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	if in.PreviousDigest != "" {
+		const prefix string = ",\"previousDigest\":"
+		out.RawString(prefix)
+		out.String(string(in.PreviousDigest))
+	}
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix)
+		out.String(string(in.Result))
+	}
+	if in.Manifests != 0 {
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		out.Int(int(in.Manifests))
+	}
+	if in.MountedBlobs != 0 {
+		const prefix string = ",\"mountedBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.MountedBlobs))
+	}
+	if in.CopiedBlobs != 0 {
+		const prefix string = ",\"copiedBlobs\":"
+		out.RawString(prefix)
+		out.Int(int(in.CopiedBlobs))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeRegistryCleanerAgentInternalPkgStatus1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeRegistryCleanerAgentInternalPkgStatus1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeRegistryCleanerAgentInternalPkgStatus1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeRegistryCleanerAgentInternalPkgStatus1(l, v)
+}
@@ -0,0 +1,105 @@
+package status
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+//easyjson:json
+type GCRun struct {
+	ID string `json:"id"`
+	// Kind is index or removal
+	Kind string `json:"kind"`
+	// Trigger is cron, api or disk
+	Trigger      string `json:"trigger"`
+	StartedAt    string `json:"startedAt"`
+	FinishedAt   string `json:"finishedAt"`
+	Result       string `json:"result"`
+	BlobsFound   int    `json:"blobsFound"`
+	BlobsRemoved int    `json:"blobsRemoved"`
+	// Bytes is the size of garbage found by index runs and reclaimed by removal runs
+	Bytes int64  `json:"bytes"`
+	Error string `json:"error,omitempty"`
+}
+
+//easyjson:json
+type GCHistory struct {
+	Runs []GCRun `json:"runs"`
+}
+
+// GCRunFilter selects history entries by kind and start time, zero values match every run
+type GCRunFilter struct {
+	Kind  string
+	Since time.Time
+	Until time.Time
+	// Last is the id of the last entry of the previous page
+	Last string
+	// N limits the number of entries, N <= 0 means all
+	N int
+}
+
+const gcRunsPrefix = "gc_runs/"
+
+// gcRunKey layout is gc_runs/<id>, ids are zero padded so keys sort chronologically
+func gcRunKey(id string) []byte {
+	return []byte(gcRunsPrefix + id)
+}
+
+// AddGCRun appends the finished run to the history, ID is assigned here; entries are never updated
+func (m *Manager) AddGCRun(run *GCRun) error {
+	run.ID = m.nextID(time.Now())
+	val, err := run.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return m.Storage.SetValue(gcRunKey(run.ID), val)
+}
+
+func (f *GCRunFilter) match(run *GCRun) bool {
+	if f.Kind != "" && run.Kind != f.Kind {
+		return false
+	}
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, run.StartedAt)
+	if err != nil {
+		return false
+	}
+	return !startedAt.Before(f.Since) && (f.Until.IsZero() || startedAt.Before(f.Until))
+}
+
+// ListGCRuns returns runs matching the filter, oldest first
+func (m *Manager) ListGCRuns(filter GCRunFilter) ([]GCRun, error) {
+	res := make([]GCRun, 0)
+	err := m.Storage.ScanValues([]byte(gcRunsPrefix), func(key []byte, value []byte) error {
+		if strings.TrimPrefix(string(key), gcRunsPrefix) <= filter.Last {
+			return nil
+		}
+		run := GCRun{}
+		if err := run.UnmarshalJSON(value); err != nil {
+			return err
+		}
+		if filter.match(&run) {
+			res = append(res, run)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+	if filter.N > 0 && len(res) > filter.N {
+		res = res[:filter.N]
+	}
+	return res, nil
+}
+
+// PruneGCRuns deletes runs recorded before the cutoff and the oldest runs beyond maxEntries,
+// a zero cutoff or maxEntries disables the respective limit
+func (m *Manager) PruneGCRuns(before time.Time, maxEntries int) (int, error) {
+	return m.pruneEntries(gcRunsPrefix, before, maxEntries)
+}
@@ -0,0 +1,246 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package status
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonAb3f06fbDecodeRegistryCleanerAgentInternalPkgStatus(in *jlexer.Lexer, out *GCRun) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "kind":
+			out.Kind = string(in.String())
+		case "trigger":
+			out.Trigger = string(in.String())
+		case "startedAt":
+			out.StartedAt = string(in.String())
+		case "finishedAt":
+			out.FinishedAt = string(in.String())
+		case "result":
+			out.Result = string(in.String())
+		case "blobsFound":
+			out.BlobsFound = int(in.Int())
+		case "blobsRemoved":
+			out.BlobsRemoved = int(in.Int())
+		case "bytes":
+			out.Bytes = int64(in.Int64())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAb3f06fbEncodeRegistryCleanerAgentInternalPkgStatus(out *jwriter.Writer, in GCRun) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"trigger\":"
+		out.RawString(prefix)
+		out.String(string(in.Trigger))
+	}
+	{
+		const prefix string = ",\"startedAt\":"
+		out.RawString(prefix)
+		out.String(string(in.StartedAt))
+	}
+	{
+		const prefix string = ",\"finishedAt\":"
+		out.RawString(prefix)
+		out.String(string(in.FinishedAt))
+	}
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix)
+		out.String(string(in.Result))
+	}
+	{
+		const prefix string = ",\"blobsFound\":"
+		out.RawString(prefix)
+		out.Int(int(in.BlobsFound))
+	}
+	{
+		const prefix string = ",\"blobsRemoved\":"
+		out.RawString(prefix)
+		out.Int(int(in.BlobsRemoved))
+	}
+	{
+		const prefix string = ",\"bytes\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Bytes))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GCRun) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAb3f06fbEncodeRegistryCleanerAgentInternalPkgStatus(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GCRun) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAb3f06fbEncodeRegistryCleanerAgentInternalPkgStatus(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GCRun) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAb3f06fbDecodeRegistryCleanerAgentInternalPkgStatus(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GCRun) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAb3f06fbDecodeRegistryCleanerAgentInternalPkgStatus(l, v)
+}
+func easyjsonAb3f06fbDecodeRegistryCleanerAgentInternalPkgStatus1(in *jlexer.Lexer, out *GCHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "runs":
+			if in.IsNull() {
+				in.Skip()
+				out.Runs = nil
+			} else {
+				in.Delim('[')
+				if out.Runs == nil {
+					if !in.IsDelim(']') {
+						out.Runs = make([]GCRun, 0, 0)
+					} else {
+						out.Runs = []GCRun{}
+					}
+				} else {
+					out.Runs = (out.Runs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 GCRun
+					(v1).UnmarshalEasyJSON(in)
+					out.Runs = append(out.Runs, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAb3f06fbEncodeRegistryCleanerAgentInternalPkgStatus1(out *jwriter.Writer, in GCHistory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"runs\":"
+		out.RawString(prefix[1:])
+		if in.Runs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Runs {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GCHistory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAb3f06fbEncodeRegistryCleanerAgentInternalPkgStatus1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GCHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAb3f06fbEncodeRegistryCleanerAgentInternalPkgStatus1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GCHistory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAb3f06fbDecodeRegistryCleanerAgentInternalPkgStatus1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GCHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAb3f06fbDecodeRegistryCleanerAgentInternalPkgStatus1(l, v)
+}
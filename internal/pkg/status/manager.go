@@ -1,14 +1,25 @@
 package status
 
 import (
+	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 type Manager struct {
 	Storage *Storage
 	Status  *Status
+	// statusMu guards Status fields, handlers read them through Snapshot
+	statusMu *sync.RWMutex
+	pullsMu  *sync.Mutex
+	searchMu *sync.RWMutex
+	idMu     *sync.Mutex
+	// lastID keeps ids of audit and history entries increasing when the clock does not
+	lastID int64
 }
 
 func InitStatusManager(storagePath string) (*Manager, error) {
@@ -19,8 +30,12 @@ func InitStatusManager(storagePath string) (*Manager, error) {
 	}
 	status := NewStatus()
 	m := &Manager{
-		Storage: storage,
-		Status:  status,
+		Storage:  storage,
+		Status:   status,
+		statusMu: &sync.RWMutex{},
+		pullsMu:  &sync.Mutex{},
+		searchMu: &sync.RWMutex{},
+		idMu:     &sync.Mutex{},
 	}
 	err = m.restoreStatus()
 	if err != nil {
@@ -29,6 +44,46 @@ func InitStatusManager(storagePath string) (*Manager, error) {
 	return m, nil
 }
 
+// nextID returns a zero padded id sorting chronologically, entries of the same nanosecond still get distinct ids
+func (m *Manager) nextID(now time.Time) string {
+	m.idMu.Lock()
+	defer m.idMu.Unlock()
+	id := now.UnixNano()
+	if id <= m.lastID {
+		id = m.lastID + 1
+	}
+	m.lastID = id
+	return fmt.Sprintf("%020d", id)
+}
+
+// pruneEntries deletes entries keyed by nextID under the prefix which were recorded before the cutoff,
+// then the oldest ones beyond maxEntries; a zero cutoff or maxEntries disables the respective limit
+func (m *Manager) pruneEntries(prefix string, before time.Time, maxEntries int) (int, error) {
+	var ids []string
+	err := m.Storage.ScanValues([]byte(prefix), func(key []byte, _ []byte) error {
+		ids = append(ids, strings.TrimPrefix(string(key), prefix))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(ids)
+	stale := 0
+	if !before.IsZero() {
+		cutoff := fmt.Sprintf("%020d", before.UnixNano())
+		stale = sort.SearchStrings(ids, cutoff)
+	}
+	if maxEntries > 0 && len(ids)-stale > maxEntries {
+		stale = len(ids) - maxEntries
+	}
+	for _, id := range ids[:stale] {
+		if err = m.Storage.DeleteValue([]byte(prefix + id)); err != nil {
+			return 0, err
+		}
+	}
+	return stale, nil
+}
+
 func (m *Manager) Shutdown() error {
 	return m.Storage.Close()
 }
@@ -57,20 +112,60 @@ func (m *Manager) restoreStatus() error {
 		return err
 	}
 	m.Status.BlobsTotalSize, err = strconv.ParseInt(string(val), 10, 64)
-	return err
+	if err != nil {
+		return err
+	}
+	val, err = m.Storage.GetValue(KeyDiskCleanupTriggeredAt, []byte(m.Status.DiskCleanupTriggeredAt))
+	if err != nil {
+		return err
+	}
+	m.Status.DiskCleanupTriggeredAt = string(val)
+	val, err = m.Storage.GetValue(KeyDiskCleanupTriggerUsage,
+		[]byte(strconv.FormatFloat(m.Status.DiskCleanupTriggerUsage, 'f', 2, 64)))
+	if err != nil {
+		return err
+	}
+	m.Status.DiskCleanupTriggerUsage, err = strconv.ParseFloat(string(val), 64)
+	if err != nil {
+		return err
+	}
+	val, err = m.Storage.GetValue(KeyDiskCleanupResult, []byte(m.Status.DiskCleanupResult))
+	if err != nil {
+		return err
+	}
+	m.Status.DiskCleanupResult = string(val)
+	return nil
+}
+
+// Snapshot returns a copy of the status safe to read while it is updated
+func (m *Manager) Snapshot() Status {
+	m.statusMu.RLock()
+	defer m.statusMu.RUnlock()
+	return *m.Status
 }
 
 // SetIsAlive IsAlive status is not stored persistently as it is useless
 func (m *Manager) SetIsAlive(isAlive bool) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
 	m.Status.IsAlive = isAlive
 }
 
+// SetDiskUsage Disk usage is measured periodically and not stored persistently
+func (m *Manager) SetDiskUsage(diskUsage float64) {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	m.Status.DiskUsage = diskUsage
+}
+
 func (m *Manager) SetUnusedBlobs(unusedBlobs int) error {
 	err := m.Storage.SetValue(KeyUnusedBlobs,
 		[]byte(strconv.Itoa(unusedBlobs)))
 	if err != nil {
 		return err
 	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
 	m.Status.UnusedBlobs = unusedBlobs
 	return nil
 }
@@ -81,6 +176,8 @@ func (m *Manager) SetBlobsCleanedAt(blobsCleanedAt time.Time) error {
 	if err != nil {
 		return err
 	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
 	m.Status.BlobsCleanedAt = timeStr
 	return nil
 }
@@ -91,6 +188,8 @@ func (m *Manager) SetBlobsIndexedAt(blobsIndexedAt time.Time) error {
 	if err != nil {
 		return err
 	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
 	m.Status.BlobsIndexedAt = timeStr
 	return nil
 }
@@ -101,10 +200,47 @@ func (m *Manager) SetBlobsTotalSize(blobsTotalSize int64) error {
 	if err != nil {
 		return err
 	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
 	m.Status.BlobsTotalSize = blobsTotalSize
 	return nil
 }
 
+func (m *Manager) SetDiskCleanupTriggeredAt(triggeredAt time.Time) error {
+	timeStr := triggeredAt.Format(time.RFC3339)
+	err := m.Storage.SetValue(KeyDiskCleanupTriggeredAt, []byte(timeStr))
+	if err != nil {
+		return err
+	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	m.Status.DiskCleanupTriggeredAt = timeStr
+	return nil
+}
+
+func (m *Manager) SetDiskCleanupTriggerUsage(triggerUsage float64) error {
+	err := m.Storage.SetValue(KeyDiskCleanupTriggerUsage,
+		[]byte(strconv.FormatFloat(triggerUsage, 'f', 2, 64)))
+	if err != nil {
+		return err
+	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	m.Status.DiskCleanupTriggerUsage = triggerUsage
+	return nil
+}
+
+func (m *Manager) SetDiskCleanupResult(result string) error {
+	err := m.Storage.SetValue(KeyDiskCleanupResult, []byte(result))
+	if err != nil {
+		return err
+	}
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	m.Status.DiskCleanupResult = result
+	return nil
+}
+
 func (m *Manager) UpdateStatus(update *Update) error {
 	var err error = nil
 	if update.UnusedBlobs != nil {
@@ -119,6 +255,15 @@ func (m *Manager) UpdateStatus(update *Update) error {
 	if err == nil && update.BlobsCleanedAt != nil {
 		err = m.SetBlobsCleanedAt(*update.BlobsCleanedAt)
 	}
+	if err == nil && update.DiskCleanupTriggeredAt != nil {
+		err = m.SetDiskCleanupTriggeredAt(*update.DiskCleanupTriggeredAt)
+	}
+	if err == nil && update.DiskCleanupTriggerUsage != nil {
+		err = m.SetDiskCleanupTriggerUsage(*update.DiskCleanupTriggerUsage)
+	}
+	if err == nil && update.DiskCleanupResult != nil {
+		err = m.SetDiskCleanupResult(*update.DiskCleanupResult)
+	}
 	if err != nil {
 		log.Printf("[ERROR at status.Manager.UpdateStatus]: %v", err)
 	}
@@ -0,0 +1,158 @@
+package status
+
+import "time"
+
+//easyjson:json
+type PullStats struct {
+	Name         string `json:"name"`
+	Reference    string `json:"reference"`
+	PullCount    int64  `json:"pullCount"`
+	FirstSeenAt  string `json:"firstSeenAt"`
+	LastPulledAt string `json:"lastPulledAt"`
+	LastPushedAt string `json:"lastPushedAt,omitempty"`
+	// Digest is the manifest the tag was last resolved to, set for tags only
+	Digest string `json:"digest,omitempty"`
+}
+
+const pullsPrefix = "pulls/"
+
+// pullsKey layout is pulls/<repo>@<tag or digest>, '@' never occurs in repository names
+func pullsKey(repo, reference string) []byte {
+	return []byte(pullsPrefix + repo + "@" + reference)
+}
+
+func NewPullStats(repo, reference string, seenAt time.Time) *PullStats {
+	return &PullStats{
+		Name:        repo,
+		Reference:   reference,
+		PullCount:   0,
+		FirstSeenAt: seenAt.Format(time.RFC3339),
+	}
+}
+
+// LastActivity returns the last pull or push time, or the first time the reference was seen if there was neither
+func (ps *PullStats) LastActivity() time.Time {
+	last := time.Unix(0, 0)
+	for _, ts := range []string{ps.LastPulledAt, ps.LastPushedAt} {
+		if t, err := time.Parse(time.RFC3339, ts); err == nil && t.After(last) {
+			last = t
+		}
+	}
+	if ps.LastPulledAt != "" || ps.LastPushedAt != "" {
+		return last
+	}
+	t, err := time.Parse(time.RFC3339, ps.FirstSeenAt)
+	if err != nil {
+		return last
+	}
+	return t
+}
+
+// GetPullStats returns nil stats without error for references never seen
+func (m *Manager) GetPullStats(repo, reference string) (*PullStats, error) {
+	val, err := m.Storage.GetValue(pullsKey(repo, reference), nil)
+	if err == ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	stats := &PullStats{}
+	err = stats.UnmarshalJSON(val)
+	return stats, err
+}
+
+func (m *Manager) setPullStats(stats *PullStats) error {
+	val, err := stats.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return m.Storage.SetValue(pullsKey(stats.Name, stats.Reference), val)
+}
+
+// RecordPull increments pull counter of every given reference (tag and/or digest) of the repository
+func (m *Manager) RecordPull(repo string, pulledAt time.Time, references ...string) error {
+	m.pullsMu.Lock()
+	defer m.pullsMu.Unlock()
+	for _, reference := range references {
+		if reference == "" {
+			continue
+		}
+		if err := m.countPull(repo, reference, "", pulledAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordTagPull counts a pull of the tag and keeps the digest it resolved to; clients resolve tags with HEAD
+// and fetch the manifest by digest, so the tag is only ever seen by the first request
+func (m *Manager) RecordTagPull(repo, tag, digest string, pulledAt time.Time) error {
+	m.pullsMu.Lock()
+	defer m.pullsMu.Unlock()
+	return m.countPull(repo, tag, digest, pulledAt)
+}
+
+func (m *Manager) countPull(repo, reference, digest string, pulledAt time.Time) error {
+	stats, err := m.GetPullStats(repo, reference)
+	if err != nil {
+		return err
+	}
+	if stats == nil {
+		stats = NewPullStats(repo, reference, pulledAt)
+	}
+	stats.PullCount++
+	stats.LastPulledAt = pulledAt.Format(time.RFC3339)
+	if digest != "" {
+		stats.Digest = digest
+	}
+	return m.setPullStats(stats)
+}
+
+// RecordPush records a manifest push of the digest to the repository
+func (m *Manager) RecordPush(repo, digest string, pushedAt time.Time) error {
+	m.pullsMu.Lock()
+	defer m.pullsMu.Unlock()
+	stats, err := m.GetPullStats(repo, digest)
+	if err != nil {
+		return err
+	}
+	if stats == nil {
+		stats = NewPullStats(repo, digest, pushedAt)
+	}
+	stats.LastPushedAt = pushedAt.Format(time.RFC3339)
+	return m.setPullStats(stats)
+}
+
+// MarkSeen starts tracking a reference which has never been pulled through the agent
+func (m *Manager) MarkSeen(repo, reference string, seenAt time.Time) (*PullStats, error) {
+	m.pullsMu.Lock()
+	defer m.pullsMu.Unlock()
+	stats, err := m.GetPullStats(repo, reference)
+	if err != nil || stats != nil {
+		return stats, err
+	}
+	stats = NewPullStats(repo, reference, seenAt)
+	return stats, m.setPullStats(stats)
+}
+
+func (m *Manager) DeletePullStats(repo, reference string) error {
+	m.pullsMu.Lock()
+	defer m.pullsMu.Unlock()
+	return m.Storage.DeleteValue(pullsKey(repo, reference))
+}
+
+// ListPullStats returns stats of all references of the repository
+func (m *Manager) ListPullStats(repo string) ([]PullStats, error) {
+	prefix := pullsKey(repo, "")
+	var res []PullStats
+	err := m.Storage.ScanValues(prefix, func(_ []byte, value []byte) error {
+		stats := PullStats{}
+		if err := stats.UnmarshalJSON(value); err != nil {
+			return err
+		}
+		res = append(res, stats)
+		return nil
+	})
+	return res, err
+}
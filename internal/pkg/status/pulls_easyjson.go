@@ -0,0 +1,127 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package status
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCaf9d68aDecodeRegistryCleanerAgentInternalPkgStatus(in *jlexer.Lexer, out *PullStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "reference":
+			out.Reference = string(in.String())
+		case "pullCount":
+			out.PullCount = int64(in.Int64())
+		case "firstSeenAt":
+			out.FirstSeenAt = string(in.String())
+		case "lastPulledAt":
+			out.LastPulledAt = string(in.String())
+		case "lastPushedAt":
+			out.LastPushedAt = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCaf9d68aEncodeRegistryCleanerAgentInternalPkgStatus(out *jwriter.Writer, in PullStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"reference\":"
+		out.RawString(prefix)
+		out.String(string(in.Reference))
+	}
+	{
+		const prefix string = ",\"pullCount\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.PullCount))
+	}
+	{
+		const prefix string = ",\"firstSeenAt\":"
+		out.RawString(prefix)
+		out.String(string(in.FirstSeenAt))
+	}
+	{
+		const prefix string = ",\"lastPulledAt\":"
+		out.RawString(prefix)
+		out.String(string(in.LastPulledAt))
+	}
+	if in.LastPushedAt != "" {
+		const prefix string = ",\"lastPushedAt\":"
+		out.RawString(prefix)
+		out.String(string(in.LastPushedAt))
+	}
+	if in.Digest != "" {
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PullStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCaf9d68aEncodeRegistryCleanerAgentInternalPkgStatus(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PullStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCaf9d68aEncodeRegistryCleanerAgentInternalPkgStatus(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PullStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCaf9d68aDecodeRegistryCleanerAgentInternalPkgStatus(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PullStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCaf9d68aDecodeRegistryCleanerAgentInternalPkgStatus(l, v)
+}
@@ -0,0 +1,86 @@
+package status
+
+import (
+	"bytes"
+	"time"
+)
+
+//easyjson:json
+type SearchEntry struct {
+	Name         string            `json:"name"`
+	Tag          string            `json:"tag"`
+	Digest       string            `json:"digest"`
+	MediaType    string            `json:"mediaType"`
+	Architecture string            `json:"architecture"`
+	OS           string            `json:"os"`
+	Created      string            `json:"created"`
+	Size         int64             `json:"size"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+const searchPrefix = "search/"
+
+// searchKey layout is search/<repo>:<tag>, ':' never occurs in repository names
+func searchKey(repo, tag string) []byte {
+	return []byte(searchPrefix + repo + ":" + tag)
+}
+
+// ReplaceSearchEntries stores a freshly built search index, entries of removed tags are dropped and
+// unchanged entries are not written again, as every write grows the storage until it is merged
+func (m *Manager) ReplaceSearchEntries(entries []SearchEntry, indexedAt time.Time) error {
+	m.searchMu.Lock()
+	defer m.searchMu.Unlock()
+	stored := make(map[string][]byte)
+	err := m.Storage.ScanValues([]byte(searchPrefix), func(key []byte, value []byte) error {
+		stored[string(key)] = append([]byte(nil), value...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		key := searchKey(entries[i].Name, entries[i].Tag)
+		val, err := entries[i].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		previous, ok := stored[string(key)]
+		delete(stored, string(key))
+		if ok && bytes.Equal(previous, val) {
+			continue
+		}
+		if err = m.Storage.SetValue(key, val); err != nil {
+			return err
+		}
+	}
+	for key := range stored {
+		if err = m.Storage.DeleteValue([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return m.Storage.SetValue(KeySearchIndexedAt, []byte(indexedAt.Format(time.RFC3339)))
+}
+
+// ListSearchEntries returns the stored search index and the time it was built, empty if never built
+func (m *Manager) ListSearchEntries() ([]SearchEntry, string, error) {
+	return m.scanSearchEntries([]byte(searchPrefix))
+}
+
+func (m *Manager) scanSearchEntries(prefix []byte) ([]SearchEntry, string, error) {
+	m.searchMu.RLock()
+	defer m.searchMu.RUnlock()
+	indexedAt, err := m.Storage.GetValue(KeySearchIndexedAt, []byte(""))
+	if err != nil {
+		return nil, "", err
+	}
+	var res []SearchEntry
+	err = m.Storage.ScanValues(prefix, func(_ []byte, value []byte) error {
+		entry := SearchEntry{}
+		if err := entry.UnmarshalJSON(value); err != nil {
+			return err
+		}
+		res = append(res, entry)
+		return nil
+	})
+	return res, string(indexedAt), err
+}
@@ -11,6 +11,11 @@ type Status struct {
 	BlobsCleanedAt string `json:"blobsCleanedAt"`
 	BlobsIndexedAt string `json:"blobsIndexedAt"`
 	BlobsTotalSize int64  `json:"blobsTotalSize"`
+	// DiskUsage is the used percentage of the registry storage, refreshed by the disk monitor
+	DiskUsage               float64 `json:"diskUsage"`
+	DiskCleanupTriggeredAt  string  `json:"diskCleanupTriggeredAt"`
+	DiskCleanupTriggerUsage float64 `json:"diskCleanupTriggerUsage"`
+	DiskCleanupResult       string  `json:"diskCleanupResult"`
 }
 
 func NewStatus() *Status {
@@ -20,5 +25,10 @@ func NewStatus() *Status {
 		BlobsCleanedAt: time.Unix(0, 0).Format(time.RFC3339),
 		BlobsIndexedAt: time.Unix(0, 0).Format(time.RFC3339),
 		BlobsTotalSize: 0,
+
+		DiskUsage:               0,
+		DiskCleanupTriggeredAt:  time.Unix(0, 0).Format(time.RFC3339),
+		DiskCleanupTriggerUsage: 0,
+		DiskCleanupResult:       "",
 	}
 }
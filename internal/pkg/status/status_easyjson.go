@@ -46,6 +46,14 @@ func easyjson727fe99aDecodeRegistryCleanerAgentInternalPkgStatus(in *jlexer.Lexe
 			out.BlobsIndexedAt = string(in.String())
 		case "blobsTotalSize":
 			out.BlobsTotalSize = int64(in.Int64())
+		case "diskUsage":
+			out.DiskUsage = float64(in.Float64())
+		case "diskCleanupTriggeredAt":
+			out.DiskCleanupTriggeredAt = string(in.String())
+		case "diskCleanupTriggerUsage":
+			out.DiskCleanupTriggerUsage = float64(in.Float64())
+		case "diskCleanupResult":
+			out.DiskCleanupResult = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -85,6 +93,26 @@ func easyjson727fe99aEncodeRegistryCleanerAgentInternalPkgStatus(out *jwriter.Wr
 		out.RawString(prefix)
 		out.Int64(int64(in.BlobsTotalSize))
 	}
+	{
+		const prefix string = ",\"diskUsage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.DiskUsage))
+	}
+	{
+		const prefix string = ",\"diskCleanupTriggeredAt\":"
+		out.RawString(prefix)
+		out.String(string(in.DiskCleanupTriggeredAt))
+	}
+	{
+		const prefix string = ",\"diskCleanupTriggerUsage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.DiskCleanupTriggerUsage))
+	}
+	{
+		const prefix string = ",\"diskCleanupResult\":"
+		out.RawString(prefix)
+		out.String(string(in.DiskCleanupResult))
+	}
 	out.RawByte('}')
 }
 
@@ -19,6 +19,19 @@ var (
 	KeyIndexedAt      = []byte("indexed_at")
 	KeyCleanedAt      = []byte("cleaned_at")
 	KeyBlobsTotalSize = []byte("blobs_total_size")
+
+	KeyDiskCleanupTriggeredAt  = []byte("disk_cleanup_triggered_at")
+	KeyDiskCleanupTriggerUsage = []byte("disk_cleanup_trigger_usage")
+	KeyDiskCleanupResult       = []byte("disk_cleanup_result")
+
+	KeySearchIndexedAt = []byte("search_indexed_at")
+)
+
+const (
+	MaxKeySize   = uint32(512)
+	MaxValueSize = uint64(1 << 20)
+	// MergeThreshold is the space taken by overwritten and deleted values that makes Merge rewrite data files
+	MergeThreshold = int64(16 << 20)
 )
 
 func NewStorage(storagePath string) *Storage {
@@ -29,7 +42,9 @@ func NewStorage(storagePath string) *Storage {
 }
 
 func (s *Storage) Open() error {
-	db, err := bitcask.Open(s.Path)
+	db, err := bitcask.Open(s.Path,
+		bitcask.WithMaxKeySize(MaxKeySize),
+		bitcask.WithMaxValueSize(MaxValueSize))
 	if err != nil {
 		return err
 	}
@@ -74,3 +89,39 @@ func (s *Storage) SetValue(key []byte, value []byte) error {
 	}
 	return nil
 }
+
+func (s *Storage) DeleteValue(key []byte) error {
+	if s.cask == nil {
+		return ErrStorageClosed
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cask.Delete(key)
+}
+
+// Merge rewrites data files without overwritten and deleted values once they take MergeThreshold bytes
+func (s *Storage) Merge() error {
+	if s.cask == nil {
+		return ErrStorageClosed
+	}
+	if s.cask.Reclaimable() < MergeThreshold {
+		return nil
+	}
+	return s.cask.Merge()
+}
+
+// ScanValues calls fn for every key starting with prefix; iteration stops on the first error
+func (s *Storage) ScanValues(prefix []byte, fn func(key []byte, value []byte) error) error {
+	if s.cask == nil {
+		return ErrStorageClosed
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cask.Scan(prefix, func(key []byte) error {
+		val, err := s.cask.Get(key)
+		if err != nil {
+			return err
+		}
+		return fn(key, val)
+	})
+}
@@ -0,0 +1,31 @@
+package status
+
+const summariesPrefix = "summaries/"
+
+func summaryKey(digest string) []byte {
+	return []byte(summariesPrefix + digest)
+}
+
+// GetCachedSummary returns nil without error for digests never stored
+func (m *Manager) GetCachedSummary(digest string) ([]byte, error) {
+	val, err := m.Storage.GetValue(summaryKey(digest), nil)
+	if err == ErrKeyNotFound {
+		return nil, nil
+	}
+	return val, err
+}
+
+// SetCachedSummary stores the serialized summary of the immutable manifest content
+func (m *Manager) SetCachedSummary(digest string, summary []byte) error {
+	return m.Storage.SetValue(summaryKey(digest), summary)
+}
+
+// DeleteCachedSummaries drops summaries of manifests whose blobs were removed from the storage
+func (m *Manager) DeleteCachedSummaries(digests []string) error {
+	for _, digest := range digests {
+		if err := m.Storage.DeleteValue(summaryKey(digest)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,4 +7,8 @@ type Update struct {
 	BlobsCleanedAt *time.Time
 	BlobsIndexedAt *time.Time
 	BlobsTotalSize *int64
+
+	DiskCleanupTriggeredAt  *time.Time
+	DiskCleanupTriggerUsage *float64
+	DiskCleanupResult       *string
 }
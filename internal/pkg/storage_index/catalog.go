@@ -0,0 +1,57 @@
+package storage_index
+
+//easyjson:json
+type RepositorySummary struct {
+	Name      string `json:"name"`
+	Tags      int    `json:"tags"`
+	Manifests int    `json:"manifests"`
+	// TotalSize is the logical size, the sum of sizes of every manifest with its config and layers
+	TotalSize int64 `json:"totalSize"`
+	// StoredSize counts every blob of the repository once
+	StoredSize int64 `json:"storedSize"`
+	// UniqueSize counts blobs no other repository references
+	UniqueSize int64 `json:"uniqueSize"`
+}
+
+//easyjson:json
+type CatalogSummary struct {
+	Repositories []RepositorySummary `json:"repositories"`
+}
+
+func (idx *Index) RepositorySummary(name string) RepositorySummary {
+	repo := idx.Repositories[name]
+	summary := RepositorySummary{
+		Name:      name,
+		Tags:      len(repo.Tags),
+		Manifests: len(repo.Manifests),
+	}
+	for digest := range repo.Blobs {
+		b := idx.Blobs[digest]
+		summary.StoredSize += b.Size
+		if len(b.Repositories) == 1 {
+			summary.UniqueSize += b.Size
+		}
+	}
+	for _, digest := range repo.Manifests {
+		summary.TotalSize += idx.logicalSize(digest)
+	}
+	return summary
+}
+
+// logicalSize sums the manifest with its config and layers; child manifests of a list are counted on their own
+func (idx *Index) logicalSize(digest string) int64 {
+	m, ok := idx.Manifests[digest]
+	if !ok {
+		return 0
+	}
+	size := m.Size
+	for _, reference := range m.References {
+		if _, isManifest := idx.Manifests[reference]; isManifest {
+			continue
+		}
+		if b, ok := idx.Blobs[reference]; ok {
+			size += b.Size
+		}
+	}
+	return size
+}
@@ -0,0 +1,218 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package storage_index
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson40cc99a3DecodeRegistryCleanerAgentInternalPkgStorageIndex(in *jlexer.Lexer, out *RepositorySummary) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "tags":
+			out.Tags = int(in.Int())
+		case "manifests":
+			out.Manifests = int(in.Int())
+		case "totalSize":
+			out.TotalSize = int64(in.Int64())
+		case "storedSize":
+			out.StoredSize = int64(in.Int64())
+		case "uniqueSize":
+			out.UniqueSize = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson40cc99a3EncodeRegistryCleanerAgentInternalPkgStorageIndex(out *jwriter.Writer, in RepositorySummary) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		out.Int(int(in.Tags))
+	}
+	{
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		out.Int(int(in.Manifests))
+	}
+	{
+		const prefix string = ",\"totalSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	{
+		const prefix string = ",\"storedSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.StoredSize))
+	}
+	{
+		const prefix string = ",\"uniqueSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UniqueSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RepositorySummary) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson40cc99a3EncodeRegistryCleanerAgentInternalPkgStorageIndex(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RepositorySummary) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson40cc99a3EncodeRegistryCleanerAgentInternalPkgStorageIndex(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RepositorySummary) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson40cc99a3DecodeRegistryCleanerAgentInternalPkgStorageIndex(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RepositorySummary) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson40cc99a3DecodeRegistryCleanerAgentInternalPkgStorageIndex(l, v)
+}
+func easyjson40cc99a3DecodeRegistryCleanerAgentInternalPkgStorageIndex1(in *jlexer.Lexer, out *CatalogSummary) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]RepositorySummary, 0, 1)
+					} else {
+						out.Repositories = []RepositorySummary{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 RepositorySummary
+					(v1).UnmarshalEasyJSON(in)
+					out.Repositories = append(out.Repositories, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson40cc99a3EncodeRegistryCleanerAgentInternalPkgStorageIndex1(out *jwriter.Writer, in CatalogSummary) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix[1:])
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Repositories {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CatalogSummary) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson40cc99a3EncodeRegistryCleanerAgentInternalPkgStorageIndex1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CatalogSummary) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson40cc99a3EncodeRegistryCleanerAgentInternalPkgStorageIndex1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CatalogSummary) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson40cc99a3DecodeRegistryCleanerAgentInternalPkgStorageIndex1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CatalogSummary) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson40cc99a3DecodeRegistryCleanerAgentInternalPkgStorageIndex1(l, v)
+}
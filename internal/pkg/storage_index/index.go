@@ -0,0 +1,235 @@
+package storage_index
+
+import (
+	"log"
+	"os"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"sort"
+	"time"
+)
+
+// Blob kinds
+const (
+	KindLayer    = "layer"
+	KindConfig   = "config"
+	KindManifest = "manifest"
+)
+
+type Repository struct {
+	Name string
+	// Tags maps tag names to manifest digests
+	Tags      map[string]string
+	Manifests []string
+	// Blobs holds every blob digest the repository keeps alive: links, manifests and their references
+	Blobs map[string]struct{}
+	// Referrers maps subject digests to manifests attached to them
+	Referrers map[string][]manifest.Referrer
+}
+
+type Manifest struct {
+	Digest    string
+	MediaType string
+	Size      int64
+	// References lists config and layers, or child manifests of a list
+	References []string
+	Config     string
+	// Subject is set for OCI referrers such as signatures and SBOMs
+	Subject      string
+	ArtifactType string
+}
+
+type Blob struct {
+	Digest string
+	Size   int64
+	Kind   string
+	// Missing is set for blobs referenced by repositories but absent in the blob store
+	Missing      bool
+	Repositories map[string]struct{}
+	// Manifests maps digests of referencing manifests to repositories they are stored in
+	Manifests map[string][]string
+}
+
+// Index is a snapshot of repositories, manifests and blobs kept in the registry storage
+type Index struct {
+	Repositories map[string]*Repository
+	Manifests    map[string]*Manifest
+	Blobs        map[string]*Blob
+	BuiltAt      time.Time
+	// tagged maps manifest digests to "<repository>:<tag>" references, built once with the snapshot
+	tagged map[string][]string
+}
+
+func newIndex() *Index {
+	return &Index{
+		Repositories: make(map[string]*Repository),
+		Manifests:    make(map[string]*Manifest),
+		Blobs:        make(map[string]*Blob),
+		BuiltAt:      time.Now(),
+	}
+}
+
+func (idx *Index) blob(fsa *fs_analyzer.Analyzer, digest string) *Blob {
+	b, ok := idx.Blobs[digest]
+	if ok {
+		return b
+	}
+	b = &Blob{
+		Digest:       digest,
+		Kind:         KindLayer,
+		Repositories: make(map[string]struct{}),
+		Manifests:    make(map[string][]string),
+	}
+	size, err := fsa.GetBlobSize(digest)
+	if err != nil {
+		b.Missing = true
+		if !os.IsNotExist(err) {
+			log.Printf("[ERROR at storage_index.Index.blob]: %v", err)
+		}
+	}
+	b.Size = size
+	idx.Blobs[digest] = b
+	return b
+}
+
+// manifest parses manifest blob once, the same manifest may be stored in several repositories
+func (idx *Index) manifest(fsa *fs_analyzer.Analyzer, digest string) *Manifest {
+	m, ok := idx.Manifests[digest]
+	if ok {
+		return m
+	}
+	m = &Manifest{Digest: digest}
+	idx.Manifests[digest] = m
+	data, err := fsa.ReadBlob(digest)
+	if err != nil {
+		return m
+	}
+	m.Size = int64(len(data))
+	m.MediaType = manifest.DetectMediaType(data)
+	m.Subject, m.ArtifactType = manifest.Subject(data)
+	parsed, err := manifest.Parse(m.MediaType, data)
+	if err != nil {
+		return m
+	}
+	for _, reference := range parsed.References() {
+		m.References = append(m.References, reference.Digest.String())
+	}
+	if config, ok := manifest.ConfigDescriptor(parsed); ok {
+		m.Config = config.Digest.String()
+	}
+	return m
+}
+
+func (idx *Index) addRepository(fsa *fs_analyzer.Analyzer, name string) error {
+	repo := &Repository{Name: name, Blobs: make(map[string]struct{})}
+	var err error
+	if repo.Tags, err = fsa.ListTags(name); err != nil {
+		return err
+	}
+	if repo.Manifests, err = fsa.ListManifestRevisions(name); err != nil {
+		return err
+	}
+	links, err := fsa.ListLayerLinks(name)
+	if err != nil {
+		return err
+	}
+	for _, digest := range links {
+		repo.Blobs[digest] = struct{}{}
+	}
+	for _, digest := range repo.Manifests {
+		repo.Blobs[digest] = struct{}{}
+		m := idx.manifest(fsa, digest)
+		for _, reference := range m.References {
+			repo.Blobs[reference] = struct{}{}
+			b := idx.blob(fsa, reference)
+			b.Manifests[digest] = append(b.Manifests[digest], name)
+			if manifest.IsListMediaType(m.MediaType) {
+				b.Kind = KindManifest
+			} else if reference == m.Config {
+				b.Kind = KindConfig
+			}
+		}
+	}
+	for digest := range repo.Blobs {
+		b := idx.blob(fsa, digest)
+		b.Repositories[name] = struct{}{}
+	}
+	for _, digest := range repo.Manifests {
+		idx.Blobs[digest].Kind = KindManifest
+	}
+	idx.addReferrers(fsa, repo)
+	idx.Repositories[name] = repo
+	return nil
+}
+
+// addReferrers collects OCI referrers and cosign tags of the repository by their subject
+func (idx *Index) addReferrers(fsa *fs_analyzer.Analyzer, repo *Repository) {
+	repo.Referrers = make(map[string][]manifest.Referrer)
+	for _, digest := range repo.Manifests {
+		m := idx.manifest(fsa, digest)
+		if m.Subject == "" {
+			continue
+		}
+		repo.Referrers[m.Subject] = append(repo.Referrers[m.Subject], manifest.Referrer{
+			Digest:       digest,
+			MediaType:    m.MediaType,
+			ArtifactType: m.ArtifactType,
+			Kind:         manifest.ReferrerKind(m.ArtifactType),
+		})
+	}
+	for tag, digest := range repo.Tags {
+		subject, kind, ok := manifest.CosignSubject(tag)
+		if !ok {
+			continue
+		}
+		repo.Referrers[subject] = append(repo.Referrers[subject], manifest.Referrer{
+			Digest:    digest,
+			MediaType: idx.manifest(fsa, digest).MediaType,
+			Kind:      kind,
+			Tag:       tag,
+		})
+	}
+	for _, referrers := range repo.Referrers {
+		sort.Slice(referrers, func(i, j int) bool {
+			if referrers[i].Digest != referrers[j].Digest {
+				return referrers[i].Digest < referrers[j].Digest
+			}
+			return referrers[i].Tag < referrers[j].Tag
+		})
+	}
+}
+
+// Referrers returns manifests attached to the subject in the repository
+func (idx *Index) Referrers(repo, subject string) []manifest.Referrer {
+	r, ok := idx.Repositories[repo]
+	if !ok {
+		return nil
+	}
+	return r.Referrers[subject]
+}
+
+// Build walks every repository of the registry storage
+func Build(fsa *fs_analyzer.Analyzer) (*Index, error) {
+	names, err := fsa.ListRepositories()
+	if err != nil {
+		return nil, err
+	}
+	idx := newIndex()
+	for _, name := range names {
+		if err = idx.addRepository(fsa, name); err != nil {
+			return nil, err
+		}
+	}
+	idx.tagged = idx.taggedBy()
+	return idx, nil
+}
+
+// RepositoryNames returns sorted names of indexed repositories
+func (idx *Index) RepositoryNames() []string {
+	names := make([]string, 0, len(idx.Repositories))
+	for name := range idx.Repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,238 @@
+package storage_index
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Handler struct {
+	FSAnalyzer *fs_analyzer.Analyzer
+	index      *Index
+	// mu guards index, buildMu serializes walks of the storage
+	mu         *sync.Mutex
+	buildMu    *sync.Mutex
+	refreshing int32
+}
+
+const (
+	// IndexTTL limits how often the registry storage is walked
+	IndexTTL = time.Minute
+
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+func InitIndexHandler(fsa *fs_analyzer.Analyzer) (*Handler, error) {
+	if fsa == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		FSAnalyzer: fsa,
+		mu:         &sync.Mutex{},
+		buildMu:    &sync.Mutex{},
+	}, nil
+}
+
+// snapshot returns the last built index, nil before the first build
+func (h *Handler) snapshot() *Index {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.index
+}
+
+func fresh(idx *Index) bool {
+	return idx != nil && time.Since(idx.BuiltAt) < IndexTTL
+}
+
+// Index returns a recent storage snapshot, rebuilding it when outdated
+func (h *Handler) Index() (*Index, error) {
+	if idx := h.snapshot(); fresh(idx) {
+		return idx, nil
+	}
+	h.buildMu.Lock()
+	defer h.buildMu.Unlock()
+	// Another caller may have rebuilt it meanwhile
+	if idx := h.snapshot(); fresh(idx) {
+		return idx, nil
+	}
+	idx, err := Build(h.FSAnalyzer)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	h.index = idx
+	h.mu.Unlock()
+	return idx, nil
+}
+
+// refresh rebuilds an outdated index in the background, one refresh at a time
+func (h *Handler) refresh() {
+	if !atomic.CompareAndSwapInt32(&h.refreshing, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&h.refreshing, 0)
+		if _, err := h.Index(); err != nil {
+			log.Printf("[ERROR at storage_index.Handler.refresh]: %v", err)
+		}
+	}()
+}
+
+// Referrers lists signatures, attestations and SBOMs of the manifest known to the last storage snapshot.
+// Summary requests never wait for a walk of the storage, outdated snapshots are refreshed in the background
+func (h *Handler) Referrers(repo, digest string) []manifest.Referrer {
+	idx := h.snapshot()
+	if !fresh(idx) {
+		h.refresh()
+	}
+	if idx == nil {
+		return nil
+	}
+	return idx.Referrers(repo, digest)
+}
+
+// paginate applies registry style "n" and "last" parameters to sorted names
+func paginate(w http.ResponseWriter, r *http.Request, names []string) ([]string, bool) {
+	query := r.URL.Query()
+	n := DefaultPageSize
+	if value := query.Get("n"); value != "" {
+		var err error
+		n, err = strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid page size", http.StatusBadRequest)
+			return nil, false
+		}
+		if n > MaxPageSize {
+			n = MaxPageSize
+		}
+	}
+	last := query.Get("last")
+	start := sort.SearchStrings(names, last)
+	if start < len(names) && names[start] == last {
+		start++
+	}
+	end := start + n
+	if end >= len(names) {
+		return names[start:], true
+	}
+	next := url.Values{}
+	next.Set("n", strconv.Itoa(n))
+	next.Set("last", names[end-1])
+	w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	return names[start:end], true
+}
+
+func (h *Handler) CatalogSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	idx, err := h.Index()
+	if err != nil {
+		log.Printf("[ERROR at storage_index.Handler.CatalogSummaryHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names, ok := paginate(w, r, idx.RepositoryNames())
+	if !ok {
+		return
+	}
+	catalog := CatalogSummary{Repositories: make([]RepositorySummary, 0, len(names))}
+	for _, name := range names {
+		catalog.Repositories = append(catalog.Repositories, idx.RepositorySummary(name))
+	}
+	http_response.WriteJson(w, &catalog)
+}
+
+func (h *Handler) BlobSharingHandler(w http.ResponseWriter, r *http.Request) {
+	idx, err := h.Index()
+	if err != nil {
+		log.Printf("[ERROR at storage_index.Handler.BlobSharingHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	digests, ok := paginate(w, r, idx.BlobDigests())
+	if !ok {
+		return
+	}
+	list := BlobSharingList{Blobs: make([]BlobSharing, 0, len(digests))}
+	for _, digest := range digests {
+		list.Blobs = append(list.Blobs, idx.BlobSharing(digest))
+	}
+	http_response.WriteJson(w, &list)
+}
+
+func (h *Handler) ImageSharingHandler(w http.ResponseWriter, r *http.Request) {
+	idx, err := h.Index()
+	if err != nil {
+		log.Printf("[ERROR at storage_index.Handler.ImageSharingHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	digests, ok := paginate(w, r, idx.ImageDigests())
+	if !ok {
+		return
+	}
+	list := ImageSharingList{Images: make([]ImageSharing, 0, len(digests))}
+	for _, digest := range digests {
+		list.Images = append(list.Images, idx.ImageSharing(digest))
+	}
+	http_response.WriteJson(w, &list)
+}
+
+// TopLayersHandler lists the most shared layers, "n" limits their number
+func (h *Handler) TopLayersHandler(w http.ResponseWriter, r *http.Request) {
+	n := DefaultTopLayers
+	if value := r.URL.Query().Get("n"); value != "" {
+		var err error
+		n, err = strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid number of layers", http.StatusBadRequest)
+			return
+		}
+		if n > MaxPageSize {
+			n = MaxPageSize
+		}
+	}
+	idx, err := h.Index()
+	if err != nil {
+		log.Printf("[ERROR at storage_index.Handler.TopLayersHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http_response.WriteJson(w, &BlobSharingList{Blobs: idx.TopLayers(n)})
+}
+
+func (h *Handler) BlobReferencesHandler(w http.ResponseWriter, r *http.Request) {
+	idx, err := h.Index()
+	if err != nil {
+		log.Printf("[ERROR at storage_index.Handler.BlobReferencesHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	refs, err := idx.BlobReferences(h.FSAnalyzer, mux.Vars(r)["digest"])
+	if errors.Is(err, fs_analyzer.ErrInvalidDigest) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if os.IsNotExist(err) {
+		http.Error(w, "blob not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("[ERROR at storage_index.Handler.BlobReferencesHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http_response.WriteJson(w, refs)
+}
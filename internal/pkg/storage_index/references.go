@@ -0,0 +1,133 @@
+package storage_index
+
+import (
+	"encoding/json"
+	"errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"sort"
+)
+
+//easyjson:json
+type ManifestReference struct {
+	Digest       string   `json:"digest"`
+	MediaType    string   `json:"mediaType"`
+	Repositories []string `json:"repositories"`
+	// Tags are given as "<repository>:<tag>" and include tags of manifest lists containing the manifest
+	Tags []string `json:"tags"`
+}
+
+//easyjson:json
+type BlobReferences struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+	Kind   string `json:"kind"`
+	// Missing is set for blobs referenced by repositories but absent in the blob store
+	Missing bool `json:"missing"`
+	// Repositories keep the blob alive with a layer link or a manifest revision
+	Repositories []string            `json:"repositories"`
+	Manifests    []ManifestReference `json:"manifests"`
+	// Tags are set for manifest blobs
+	Tags []string `json:"tags"`
+}
+
+// DetectKind tells the kind of a blob no manifest references from its content
+func DetectKind(fsa *fs_analyzer.Analyzer, digest string) (string, error) {
+	data, err := fsa.ReadBlob(digest)
+	if errors.Is(err, fs_analyzer.ErrBlobTooLarge) {
+		return KindLayer, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if manifest.DetectMediaType(data) != "" {
+		return KindManifest, nil
+	}
+	var config struct {
+		RootFS json.RawMessage `json:"rootfs"`
+	}
+	if json.Unmarshal(data, &config) == nil && config.RootFS != nil {
+		return KindConfig, nil
+	}
+	return KindLayer, nil
+}
+
+// taggedBy maps manifest digests to "<repository>:<tag>" references pointing at them, sorted
+func (idx *Index) taggedBy() map[string][]string {
+	tagged := make(map[string][]string)
+	for name, repo := range idx.Repositories {
+		for tag, digest := range repo.Tags {
+			tagged[digest] = append(tagged[digest], name+":"+tag)
+		}
+	}
+	for _, references := range tagged {
+		sort.Strings(references)
+	}
+	return tagged
+}
+
+// tagsOf returns tags pointing to the manifest directly or through manifest lists
+func (idx *Index) tagsOf(digest string) []string {
+	visited := make(map[string]struct{})
+	tags := make([]string, 0)
+	queue := []string{digest}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+		tags = append(tags, idx.tagged[current]...)
+		if b, ok := idx.Blobs[current]; ok {
+			for list := range b.Manifests {
+				queue = append(queue, list)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// BlobReferences describes who references the blob; blobs unknown to the index are read from the storage
+func (idx *Index) BlobReferences(fsa *fs_analyzer.Analyzer, digest string) (*BlobReferences, error) {
+	refs := &BlobReferences{
+		Digest:       digest,
+		Repositories: []string{},
+		Manifests:    []ManifestReference{},
+		Tags:         []string{},
+	}
+	b, ok := idx.Blobs[digest]
+	if !ok {
+		size, err := fsa.GetBlobSize(digest)
+		if err != nil {
+			return nil, err
+		}
+		refs.Size = size
+		refs.Kind, err = DetectKind(fsa, digest)
+		return refs, err
+	}
+	refs.Size = b.Size
+	refs.Kind = b.Kind
+	refs.Missing = b.Missing
+	refs.Repositories = sortedKeys(b.Repositories)
+	for manifestDigest, repositories := range b.Manifests {
+		reference := ManifestReference{
+			Digest:       manifestDigest,
+			Repositories: append([]string(nil), repositories...),
+			Tags:         idx.tagsOf(manifestDigest),
+		}
+		if m, ok := idx.Manifests[manifestDigest]; ok {
+			reference.MediaType = m.MediaType
+		}
+		sort.Strings(reference.Repositories)
+		refs.Manifests = append(refs.Manifests, reference)
+	}
+	sort.Slice(refs.Manifests, func(i, j int) bool {
+		return refs.Manifests[i].Digest < refs.Manifests[j].Digest
+	})
+	if b.Kind == KindManifest {
+		refs.Tags = idx.tagsOf(digest)
+	}
+	return refs, nil
+}
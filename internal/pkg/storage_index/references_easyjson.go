@@ -0,0 +1,374 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package storage_index
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson207e70eeDecodeRegistryCleanerAgentInternalPkgStorageIndex(in *jlexer.Lexer, out *ManifestReference) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Repositories = append(out.Repositories, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.Tags = append(out.Tags, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson207e70eeEncodeRegistryCleanerAgentInternalPkgStorageIndex(out *jwriter.Writer, in ManifestReference) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.Repositories {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v4))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Tags {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ManifestReference) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson207e70eeEncodeRegistryCleanerAgentInternalPkgStorageIndex(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ManifestReference) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson207e70eeEncodeRegistryCleanerAgentInternalPkgStorageIndex(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ManifestReference) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson207e70eeDecodeRegistryCleanerAgentInternalPkgStorageIndex(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ManifestReference) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson207e70eeDecodeRegistryCleanerAgentInternalPkgStorageIndex(l, v)
+}
+func easyjson207e70eeDecodeRegistryCleanerAgentInternalPkgStorageIndex1(in *jlexer.Lexer, out *BlobReferences) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "kind":
+			out.Kind = string(in.String())
+		case "missing":
+			out.Missing = bool(in.Bool())
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Repositories = append(out.Repositories, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "manifests":
+			if in.IsNull() {
+				in.Skip()
+				out.Manifests = nil
+			} else {
+				in.Delim('[')
+				if out.Manifests == nil {
+					if !in.IsDelim(']') {
+						out.Manifests = make([]ManifestReference, 0, 0)
+					} else {
+						out.Manifests = []ManifestReference{}
+					}
+				} else {
+					out.Manifests = (out.Manifests)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 ManifestReference
+					(v8).UnmarshalEasyJSON(in)
+					out.Manifests = append(out.Manifests, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 string
+					v9 = string(in.String())
+					out.Tags = append(out.Tags, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson207e70eeEncodeRegistryCleanerAgentInternalPkgStorageIndex1(out *jwriter.Writer, in BlobReferences) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"missing\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Missing))
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Repositories {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v11))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		if in.Manifests == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Manifests {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				(v13).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Tags {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BlobReferences) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson207e70eeEncodeRegistryCleanerAgentInternalPkgStorageIndex1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BlobReferences) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson207e70eeEncodeRegistryCleanerAgentInternalPkgStorageIndex1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BlobReferences) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson207e70eeDecodeRegistryCleanerAgentInternalPkgStorageIndex1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BlobReferences) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson207e70eeDecodeRegistryCleanerAgentInternalPkgStorageIndex1(l, v)
+}
@@ -0,0 +1,133 @@
+package storage_index
+
+import (
+	"sort"
+)
+
+// DefaultTopLayers is the number of most shared layers reported when "n" is not given
+const DefaultTopLayers = 10
+
+//easyjson:json
+type BlobSharing struct {
+	Digest       string   `json:"digest"`
+	Size         int64    `json:"size"`
+	Kind         string   `json:"kind"`
+	Manifests    int      `json:"manifests"`
+	Repositories []string `json:"repositories"`
+}
+
+//easyjson:json
+type BlobSharingList struct {
+	Blobs []BlobSharing `json:"blobs"`
+}
+
+//easyjson:json
+type ImageSharing struct {
+	Digest       string   `json:"digest"`
+	MediaType    string   `json:"mediaType"`
+	Repositories []string `json:"repositories"`
+	// Tags are given as "<repository>:<tag>"
+	Tags      []string `json:"tags"`
+	TotalSize int64    `json:"totalSize"`
+	// UniqueSize counts config and layers no other manifest references
+	UniqueSize int64 `json:"uniqueSize"`
+	SharedSize int64 `json:"sharedSize"`
+}
+
+//easyjson:json
+type ImageSharingList struct {
+	Images []ImageSharing `json:"images"`
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// BlobDigests returns sorted digests of every indexed blob present in the storage
+func (idx *Index) BlobDigests() []string {
+	digests := make([]string, 0, len(idx.Blobs))
+	for digest, b := range idx.Blobs {
+		if !b.Missing {
+			digests = append(digests, digest)
+		}
+	}
+	sort.Strings(digests)
+	return digests
+}
+
+func (idx *Index) BlobSharing(digest string) BlobSharing {
+	b := idx.Blobs[digest]
+	return BlobSharing{
+		Digest:       digest,
+		Size:         b.Size,
+		Kind:         b.Kind,
+		Manifests:    len(b.Manifests),
+		Repositories: sortedKeys(b.Repositories),
+	}
+}
+
+// TopLayers returns layers referenced by the largest number of manifests, bigger layers first on ties
+func (idx *Index) TopLayers(n int) []BlobSharing {
+	layers := make([]*Blob, 0)
+	for _, b := range idx.Blobs {
+		if b.Kind == KindLayer && !b.Missing && len(b.Manifests) > 0 {
+			layers = append(layers, b)
+		}
+	}
+	sort.Slice(layers, func(i, j int) bool {
+		if len(layers[i].Manifests) != len(layers[j].Manifests) {
+			return len(layers[i].Manifests) > len(layers[j].Manifests)
+		}
+		if layers[i].Size != layers[j].Size {
+			return layers[i].Size > layers[j].Size
+		}
+		return layers[i].Digest < layers[j].Digest
+	})
+	if n < len(layers) {
+		layers = layers[:n]
+	}
+	top := make([]BlobSharing, 0, len(layers))
+	for _, b := range layers {
+		top = append(top, idx.BlobSharing(b.Digest))
+	}
+	return top
+}
+
+// ImageDigests returns sorted digests of indexed image manifests, manifest lists are skipped
+func (idx *Index) ImageDigests() []string {
+	digests := make([]string, 0, len(idx.Manifests))
+	for digest, m := range idx.Manifests {
+		if m.Config != "" {
+			digests = append(digests, digest)
+		}
+	}
+	sort.Strings(digests)
+	return digests
+}
+
+func (idx *Index) ImageSharing(digest string) ImageSharing {
+	m := idx.Manifests[digest]
+	image := ImageSharing{
+		Digest:       digest,
+		MediaType:    m.MediaType,
+		Repositories: []string{},
+		Tags:         append([]string{}, idx.tagged[digest]...),
+	}
+	if b, ok := idx.Blobs[digest]; ok {
+		image.Repositories = sortedKeys(b.Repositories)
+	}
+	for _, reference := range m.References {
+		b := idx.Blobs[reference]
+		image.TotalSize += b.Size
+		if len(b.Manifests) == 1 {
+			image.UniqueSize += b.Size
+		}
+	}
+	image.SharedSize = image.TotalSize - image.UniqueSize
+	return image
+}
@@ -0,0 +1,513 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package storage_index
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex(in *jlexer.Lexer, out *ImageSharingList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "images":
+			if in.IsNull() {
+				in.Skip()
+				out.Images = nil
+			} else {
+				in.Delim('[')
+				if out.Images == nil {
+					if !in.IsDelim(']') {
+						out.Images = make([]ImageSharing, 0, 0)
+					} else {
+						out.Images = []ImageSharing{}
+					}
+				} else {
+					out.Images = (out.Images)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ImageSharing
+					(v1).UnmarshalEasyJSON(in)
+					out.Images = append(out.Images, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex(out *jwriter.Writer, in ImageSharingList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"images\":"
+		out.RawString(prefix[1:])
+		if in.Images == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Images {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImageSharingList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImageSharingList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImageSharingList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImageSharingList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex(l, v)
+}
+func easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex1(in *jlexer.Lexer, out *ImageSharing) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "mediaType":
+			out.MediaType = string(in.String())
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Repositories = append(out.Repositories, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Tags = append(out.Tags, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "totalSize":
+			out.TotalSize = int64(in.Int64())
+		case "uniqueSize":
+			out.UniqueSize = int64(in.Int64())
+		case "sharedSize":
+			out.SharedSize = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex1(out *jwriter.Writer, in ImageSharing) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"mediaType\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v6, v7 := range in.Repositories {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v7))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Tags {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"totalSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	{
+		const prefix string = ",\"uniqueSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UniqueSize))
+	}
+	{
+		const prefix string = ",\"sharedSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.SharedSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImageSharing) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImageSharing) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImageSharing) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImageSharing) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex1(l, v)
+}
+func easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex2(in *jlexer.Lexer, out *BlobSharingList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "blobs":
+			if in.IsNull() {
+				in.Skip()
+				out.Blobs = nil
+			} else {
+				in.Delim('[')
+				if out.Blobs == nil {
+					if !in.IsDelim(']') {
+						out.Blobs = make([]BlobSharing, 0, 0)
+					} else {
+						out.Blobs = []BlobSharing{}
+					}
+				} else {
+					out.Blobs = (out.Blobs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 BlobSharing
+					(v10).UnmarshalEasyJSON(in)
+					out.Blobs = append(out.Blobs, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex2(out *jwriter.Writer, in BlobSharingList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"blobs\":"
+		out.RawString(prefix[1:])
+		if in.Blobs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Blobs {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BlobSharingList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BlobSharingList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BlobSharingList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BlobSharingList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex2(l, v)
+}
+func easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex3(in *jlexer.Lexer, out *BlobSharing) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "kind":
+			out.Kind = string(in.String())
+		case "manifests":
+			out.Manifests = int(in.Int())
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]string, 0, 4)
+					} else {
+						out.Repositories = []string{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 string
+					v13 = string(in.String())
+					out.Repositories = append(out.Repositories, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex3(out *jwriter.Writer, in BlobSharing) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		out.Int(int(in.Manifests))
+	}
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix)
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Repositories {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BlobSharing) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BlobSharing) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c517212EncodeRegistryCleanerAgentInternalPkgStorageIndex3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BlobSharing) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BlobSharing) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c517212DecodeRegistryCleanerAgentInternalPkgStorageIndex3(l, v)
+}
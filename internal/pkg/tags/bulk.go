@@ -0,0 +1,189 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"github.com/docker/distribution/reference"
+	"golang.org/x/sync/semaphore"
+	"log"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"sort"
+	"strings"
+)
+
+// Bulk item results
+const (
+	ResultDeleted  = "deleted"
+	ResultResolved = "resolved"
+	ResultNotFound = "not_found"
+	ResultDenied   = "denied"
+	ResultError    = "error"
+)
+
+const (
+	// BulkConcurrency bounds upstream deletions of a single bulk request
+	BulkConcurrency = 8
+	// MaxBulkReferences limits the number of references accepted in one request
+	MaxBulkReferences = 1000
+)
+
+//easyjson:json
+type BulkRequest struct {
+	// References are given as "<name>:<tag>" or "<name>@<digest>"
+	References []string `json:"references"`
+	// DryRun only resolves digests and tags which would be removed
+	DryRun bool `json:"dryRun"`
+	// Force allows deleting tags which share their manifest with other tags
+	Force bool `json:"force"`
+}
+
+//easyjson:json
+type BulkItem struct {
+	Reference string `json:"reference"`
+	Name      string `json:"name,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	// RemovedTags lists tags other than the requested one which pointed to the deleted manifest
+	RemovedTags []string `json:"removedTags,omitempty"`
+	Result      string   `json:"result"`
+	Error       string   `json:"error,omitempty"`
+}
+
+//easyjson:json
+type BulkReport struct {
+	DryRun bool       `json:"dryRun"`
+	Items  []BulkItem `json:"items"`
+}
+
+// tagsOf returns tags of the repository pointing to the digest
+func (th *Handler) tagsOf(repo, digest string) ([]string, error) {
+	repoTags, err := th.FSAnalyzer.ListTags(repo)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0)
+	for tag, tagged := range repoTags {
+		if tagged == digest {
+			res = append(res, tag)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+// hasRegistryHost tells whether the first name component is a registry host, as in "localhost:5000/app"
+func hasRegistryHost(name string) bool {
+	i := strings.IndexRune(name, '/')
+	if i < 0 {
+		return false
+	}
+	host := name[:i]
+	return strings.ContainsAny(host, ".:") || host == "localhost"
+}
+
+func (item *BulkItem) fail(err error) {
+	switch {
+	case errors.Is(err, ErrTagNotFound), errors.Is(err, registry_client.ErrNotFound):
+		item.Result = ResultNotFound
+	case errors.Is(err, ErrTagShared), errors.Is(err, registry_client.ErrDenied):
+		item.Result = ResultDenied
+	default:
+		item.Result = ResultError
+	}
+	item.Error = err.Error()
+}
+
+func (th *Handler) bulkItem(ref string, dryRun, force bool) BulkItem {
+	item := BulkItem{Reference: ref}
+	parsed, err := reference.Parse(ref)
+	if err != nil {
+		item.fail(err)
+		return item
+	}
+	named, ok := parsed.(reference.Named)
+	if !ok {
+		item.fail(reference.ErrNameEmpty)
+		return item
+	}
+	if hasRegistryHost(named.Name()) {
+		item.fail(ErrRegistryHost)
+		return item
+	}
+	item.Name = named.Name()
+	removed := make([]string, 0)
+	if digested, ok := parsed.(reference.Digested); ok {
+		item.Digest = digested.Digest().String()
+		if item.RemovedTags, err = th.tagsOf(item.Name, item.Digest); err != nil {
+			item.fail(err)
+			return item
+		}
+		if dryRun {
+			_, err = th.Client.HeadManifest(item.Name, item.Digest)
+		} else {
+			err = th.Client.DeleteManifest(item.Name, item.Digest)
+		}
+	} else if tagged, ok := parsed.(reference.Tagged); ok {
+		var others []string
+		item.Digest, others, err = Resolve(th.FSAnalyzer, item.Name, tagged.Tag())
+		if err == nil {
+			item.RemovedTags = others
+			removed = append(removed, tagged.Tag())
+		}
+		if err == nil && len(others) != 0 && !force {
+			err = ErrTagShared
+		}
+		if err == nil && !dryRun {
+			err = th.Client.DeleteManifest(item.Name, item.Digest)
+		}
+	} else {
+		err = errors.New("reference must contain a tag or a digest")
+	}
+	if err != nil {
+		item.fail(err)
+		return item
+	}
+	if dryRun {
+		item.Result = ResultResolved
+		return item
+	}
+	item.Result = ResultDeleted
+	log.Printf("[INFO at tags.Handler.bulkItem]: deleted %s (%s), removed tags %v", ref, item.Digest, item.RemovedTags)
+	removed = append(removed, item.RemovedTags...)
+	for _, reference := range append(removed, item.Digest) {
+		if err = th.StatusManager.DeletePullStats(item.Name, reference); err != nil {
+			log.Printf("[ERROR at tags.Handler.bulkItem]: %v", err)
+		}
+	}
+	return item
+}
+
+// BulkDelete processes every reference independently, failures are reported per item.
+// Repeated references are processed once and reported at each position
+func (th *Handler) BulkDelete(ctx context.Context, req *BulkRequest) (*BulkReport, error) {
+	sem := semaphore.NewWeighted(BulkConcurrency)
+	unique := make([]string, 0, len(req.References))
+	positions := make(map[string]int, len(req.References))
+	for _, ref := range req.References {
+		if _, ok := positions[ref]; !ok {
+			positions[ref] = len(unique)
+			unique = append(unique, ref)
+		}
+	}
+	items := make([]BulkItem, len(unique))
+	for i, ref := range unique {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		go func(i int, ref string) {
+			defer sem.Release(1)
+			items[i] = th.bulkItem(ref, req.DryRun, req.Force)
+		}(i, ref)
+	}
+	if err := sem.Acquire(ctx, BulkConcurrency); err != nil {
+		return nil, err
+	}
+	report := &BulkReport{DryRun: req.DryRun, Items: make([]BulkItem, len(req.References))}
+	for i, ref := range req.References {
+		report.Items[i] = items[positions[ref]]
+	}
+	return report, nil
+}
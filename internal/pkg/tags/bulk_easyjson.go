@@ -0,0 +1,367 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package tags
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags(in *jlexer.Lexer, out *BulkRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "references":
+			if in.IsNull() {
+				in.Skip()
+				out.References = nil
+			} else {
+				in.Delim('[')
+				if out.References == nil {
+					if !in.IsDelim(']') {
+						out.References = make([]string, 0, 4)
+					} else {
+						out.References = []string{}
+					}
+				} else {
+					out.References = (out.References)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.References = append(out.References, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dryRun":
+			out.DryRun = bool(in.Bool())
+		case "force":
+			out.Force = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags(out *jwriter.Writer, in BulkRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"references\":"
+		out.RawString(prefix[1:])
+		if in.References == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.References {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"dryRun\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.DryRun))
+	}
+	{
+		const prefix string = ",\"force\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Force))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BulkRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BulkRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BulkRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BulkRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags(l, v)
+}
+func easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags1(in *jlexer.Lexer, out *BulkReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "dryRun":
+			out.DryRun = bool(in.Bool())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]BulkItem, 0, 0)
+					} else {
+						out.Items = []BulkItem{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 BulkItem
+					(v4).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags1(out *jwriter.Writer, in BulkReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"dryRun\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.DryRun))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Items {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BulkReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BulkReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BulkReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BulkReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags1(l, v)
+}
+func easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags2(in *jlexer.Lexer, out *BulkItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reference":
+			out.Reference = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "removedTags":
+			if in.IsNull() {
+				in.Skip()
+				out.RemovedTags = nil
+			} else {
+				in.Delim('[')
+				if out.RemovedTags == nil {
+					if !in.IsDelim(']') {
+						out.RemovedTags = make([]string, 0, 4)
+					} else {
+						out.RemovedTags = []string{}
+					}
+				} else {
+					out.RemovedTags = (out.RemovedTags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.RemovedTags = append(out.RemovedTags, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "result":
+			out.Result = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags2(out *jwriter.Writer, in BulkItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reference\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reference))
+	}
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if in.Digest != "" {
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	if len(in.RemovedTags) != 0 {
+		const prefix string = ",\"removedTags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.RemovedTags {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix)
+		out.String(string(in.Result))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BulkItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BulkItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson96d41fe8EncodeRegistryCleanerAgentInternalPkgTags2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BulkItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BulkItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson96d41fe8DecodeRegistryCleanerAgentInternalPkgTags2(l, v)
+}
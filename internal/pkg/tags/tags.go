@@ -0,0 +1,44 @@
+package tags
+
+import (
+	"errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"sort"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagShared is returned when other tags point to the same manifest and deletion is not forced
+	ErrTagShared = errors.New("manifest is referenced by other tags")
+	// ErrRegistryHost rejects references naming a registry, the agent only manages its own
+	ErrRegistryHost = errors.New("reference must not contain a registry host")
+)
+
+//easyjson:json
+type DeleteReport struct {
+	Name   string `json:"name"`
+	Tag    string `json:"tag"`
+	Digest string `json:"digest"`
+	// RemovedTags lists other tags which pointed to the deleted manifest
+	RemovedTags []string `json:"removedTags"`
+}
+
+// Resolve returns the manifest digest of the tag and other tags pointing to it
+func Resolve(fsa *fs_analyzer.Analyzer, repo, tag string) (string, []string, error) {
+	repoTags, err := fsa.ListTags(repo)
+	if err != nil {
+		return "", nil, err
+	}
+	digest, ok := repoTags[tag]
+	if !ok {
+		return "", nil, ErrTagNotFound
+	}
+	others := make([]string, 0)
+	for other, otherDigest := range repoTags {
+		if other != tag && otherDigest == digest {
+			others = append(others, other)
+		}
+	}
+	sort.Strings(others)
+	return digest, others, nil
+}
@@ -0,0 +1,138 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package tags
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson2aad9953DecodeRegistryCleanerAgentInternalPkgTags(in *jlexer.Lexer, out *DeleteReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "tag":
+			out.Tag = string(in.String())
+		case "digest":
+			out.Digest = string(in.String())
+		case "removedTags":
+			if in.IsNull() {
+				in.Skip()
+				out.RemovedTags = nil
+			} else {
+				in.Delim('[')
+				if out.RemovedTags == nil {
+					if !in.IsDelim(']') {
+						out.RemovedTags = make([]string, 0, 4)
+					} else {
+						out.RemovedTags = []string{}
+					}
+				} else {
+					out.RemovedTags = (out.RemovedTags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.RemovedTags = append(out.RemovedTags, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2aad9953EncodeRegistryCleanerAgentInternalPkgTags(out *jwriter.Writer, in DeleteReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix)
+		out.String(string(in.Tag))
+	}
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix)
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"removedTags\":"
+		out.RawString(prefix)
+		if in.RemovedTags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.RemovedTags {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeleteReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2aad9953EncodeRegistryCleanerAgentInternalPkgTags(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeleteReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2aad9953EncodeRegistryCleanerAgentInternalPkgTags(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeleteReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2aad9953DecodeRegistryCleanerAgentInternalPkgTags(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeleteReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2aad9953DecodeRegistryCleanerAgentInternalPkgTags(l, v)
+}
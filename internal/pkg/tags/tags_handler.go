@@ -0,0 +1,95 @@
+package tags
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"registry-cleaner-agent/internal/pkg/status"
+)
+
+type Handler struct {
+	FSAnalyzer    *fs_analyzer.Analyzer
+	Client        *registry_client.Client
+	StatusManager *status.Manager
+}
+
+func InitTagsHandler(fsa *fs_analyzer.Analyzer, client *registry_client.Client, stm *status.Manager) (*Handler, error) {
+	if fsa == nil || client == nil || stm == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		FSAnalyzer:    fsa,
+		Client:        client,
+		StatusManager: stm,
+	}, nil
+}
+
+// Delete removes the manifest the tag points to; the registry drops every tag of the manifest with it
+func (th *Handler) Delete(repo, tag string, force bool) (*DeleteReport, error) {
+	digest, others, err := Resolve(th.FSAnalyzer, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+	report := &DeleteReport{Name: repo, Tag: tag, Digest: digest, RemovedTags: others}
+	if len(others) != 0 && !force {
+		return report, ErrTagShared
+	}
+	err = th.Client.DeleteManifest(repo, digest)
+	if err != nil {
+		return report, err
+	}
+	log.Printf("[INFO at tags.Handler.Delete]: deleted %s:%s (%s), also removed tags %v", repo, tag, digest, others)
+	for _, reference := range append([]string{tag, digest}, others...) {
+		if err = th.StatusManager.DeletePullStats(repo, reference); err != nil {
+			log.Printf("[ERROR at tags.Handler.Delete]: %v", err)
+		}
+	}
+	return report, nil
+}
+
+// TagDeleteHandler deletes the tagged manifest, "force=true" allows removing other tags of the same manifest
+func (th *Handler) TagDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	report, err := th.Delete(vars["repo"], vars["tag"], r.URL.Query().Get("force") == "true")
+	switch {
+	case errors.Is(err, ErrTagNotFound), errors.Is(err, registry_client.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrTagShared):
+		agent_errors.WriteRegistryError(w, http.StatusConflict, agent_errors.CodeDenied,
+			"manifest is referenced by other tags, use force=true to delete them too", report.RemovedTags)
+	case errors.Is(err, registry_client.ErrDenied):
+		agent_errors.WriteRegistryError(w, http.StatusForbidden, agent_errors.CodeDenied, err.Error(), nil)
+	case err != nil:
+		log.Printf("[ERROR at tags.Handler.TagDeleteHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		http_response.WriteJson(w, report)
+	}
+}
+
+func (th *Handler) BulkDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	req := &BulkRequest{}
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.References) == 0 || len(req.References) > MaxBulkReferences {
+		http.Error(w, fmt.Sprintf("from 1 to %d references are expected", MaxBulkReferences), http.StatusBadRequest)
+		return
+	}
+	report, err := th.BulkDelete(r.Context(), req)
+	if err != nil {
+		log.Printf("[ERROR at tags.Handler.BulkDeleteHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http_response.WriteJson(w, report)
+}
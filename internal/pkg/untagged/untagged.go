@@ -0,0 +1,280 @@
+package untagged
+
+import (
+	"log"
+	"os"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/manifest"
+	"sort"
+	"time"
+)
+
+//easyjson:json
+type Inventory struct {
+	Name      string             `json:"name"`
+	Manifests []manifest.Summary `json:"manifests"`
+	TotalSize int64              `json:"totalSize"`
+}
+
+//easyjson:json
+type InventoryList struct {
+	Repositories []Inventory `json:"repositories"`
+}
+
+//easyjson:json
+type DeleteFailure struct {
+	Digest string `json:"digest"`
+	Error  string `json:"error"`
+}
+
+//easyjson:json
+type DeleteReport struct {
+	Name    string          `json:"name"`
+	Deleted []string        `json:"deleted"`
+	Failed  []DeleteFailure `json:"failed"`
+}
+
+// reachable collects tagged manifests and manifests referenced by tagged lists
+func reachable(fsa *fs_analyzer.Analyzer, tags map[string]string) map[string]struct{} {
+	visited := make(map[string]struct{})
+	queue := make([]string, 0, len(tags))
+	for _, digest := range tags {
+		queue = append(queue, digest)
+	}
+	for len(queue) > 0 {
+		digest := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[digest]; ok {
+			continue
+		}
+		visited[digest] = struct{}{}
+		data, err := fsa.ReadBlob(digest)
+		if err != nil {
+			continue
+		}
+		m, err := manifest.Parse(manifest.DetectMediaType(data), data)
+		if err != nil || !manifest.IsList(m) {
+			continue
+		}
+		for _, child := range m.References() {
+			queue = append(queue, child.Digest.String())
+		}
+	}
+	return visited
+}
+
+// referrerSubjects maps OCI referrer manifests of the repository to their subjects
+func referrerSubjects(fsa *fs_analyzer.Analyzer, revisions []string) map[string]string {
+	subjects := make(map[string]string)
+	for _, digest := range revisions {
+		data, err := fsa.ReadBlob(digest)
+		if err != nil {
+			continue
+		}
+		if subject, _ := manifest.Subject(data); subject != "" {
+			subjects[digest] = subject
+		}
+	}
+	return subjects
+}
+
+// ListUntagged returns digests of manifests no tag leads to. Referrers, by OCI subject or cosign tag,
+// are kept while their subject is kept and are listed once it is gone
+func ListUntagged(fsa *fs_analyzer.Analyzer, repo string) ([]string, error) {
+	revisions, err := fsa.ListManifestRevisions(repo)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := fsa.ListTags(repo)
+	if err != nil {
+		return nil, err
+	}
+	cosignTags := make(map[string]string)
+	for tag, digest := range tags {
+		if _, _, ok := manifest.CosignSubject(tag); ok {
+			cosignTags[tag] = digest
+			delete(tags, tag)
+		}
+	}
+	tagged := reachable(fsa, tags)
+	subjects := referrerSubjects(fsa, revisions)
+	// referrers may have referrers themselves, e.g. a signature of an SBOM
+	for changed := true; changed; {
+		changed = false
+		for digest, subject := range subjects {
+			if _, ok := tagged[subject]; ok {
+				tagged[digest] = struct{}{}
+				delete(subjects, digest)
+				changed = true
+			}
+		}
+		for tag, digest := range cosignTags {
+			subject, _, _ := manifest.CosignSubject(tag)
+			if _, ok := tagged[subject]; ok {
+				for kept := range reachable(fsa, map[string]string{tag: digest}) {
+					tagged[kept] = struct{}{}
+				}
+				delete(cosignTags, tag)
+				changed = true
+			}
+		}
+	}
+	untagged := make([]string, 0)
+	for _, digest := range revisions {
+		if _, ok := tagged[digest]; !ok {
+			untagged = append(untagged, digest)
+		}
+	}
+	sort.Strings(untagged)
+	return untagged, nil
+}
+
+// ListCollectable returns untagged manifests of the repository linked before the cutoff. Younger ones are left
+// alone: pushes of manifest lists upload children by digest before the list is tagged
+func ListCollectable(fsa *fs_analyzer.Analyzer, repo string, cutoff time.Time) ([]string, error) {
+	untagged, err := ListUntagged(fsa, repo)
+	if err != nil {
+		return nil, err
+	}
+	collectable := untagged[:0]
+	for _, digest := range untagged {
+		linkedAt, err := fsa.GetManifestRevisionModTime(repo, digest)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if linkedAt.Before(cutoff) {
+			collectable = append(collectable, digest)
+		}
+	}
+	return collectable, nil
+}
+
+// Garbage lists blobs which become garbage once collectable untagged manifests of every repository are deleted:
+// the manifests themselves and blobs they reference that no other manifest revision references
+func Garbage(fsa *fs_analyzer.Analyzer, cutoff time.Time) ([]string, error) {
+	repositories, err := fsa.ListRepositories()
+	if err != nil {
+		return nil, err
+	}
+	marked := make(map[string]struct{})
+	candidates := make(map[string]struct{})
+	for _, repo := range repositories {
+		revisions, err := fsa.ListManifestRevisions(repo)
+		if err != nil {
+			return nil, err
+		}
+		collectable, err := ListCollectable(fsa, repo, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		deleted := make(map[string]struct{}, len(collectable))
+		for _, digest := range collectable {
+			deleted[digest] = struct{}{}
+		}
+		for _, digest := range revisions {
+			set := marked
+			if _, ok := deleted[digest]; ok {
+				set = candidates
+			}
+			set[digest] = struct{}{}
+			for _, blob := range references(fsa, digest) {
+				set[blob] = struct{}{}
+			}
+		}
+	}
+	var blobs []string
+	for digest := range candidates {
+		if _, ok := marked[digest]; ok {
+			continue
+		}
+		// Blobs referenced but never uploaded are no garbage
+		if _, err := fsa.GetBlobSize(digest); err != nil {
+			continue
+		}
+		blobs = append(blobs, digest)
+	}
+	sort.Strings(blobs)
+	return blobs, nil
+}
+
+// references lists blobs and child manifests the stored manifest points at, unreadable manifests have none
+func references(fsa *fs_analyzer.Analyzer, digest string) []string {
+	data, err := fsa.ReadBlob(digest)
+	if err != nil {
+		return nil
+	}
+	m, err := manifest.Parse(manifest.DetectMediaType(data), data)
+	if err != nil {
+		return nil
+	}
+	var res []string
+	for _, descriptor := range m.References() {
+		res = append(res, descriptor.Digest.String())
+	}
+	return res
+}
+
+// recheck confirms right before a deletion that no tag leads to the manifest, reachability is computed
+// again whenever tags of the repository changed since the previous check
+type recheck struct {
+	fsa      *fs_analyzer.Analyzer
+	repo     string
+	tags     map[string]string
+	untagged map[string]struct{}
+}
+
+func sameTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for tag, digest := range a {
+		if b[tag] != digest {
+			return false
+		}
+	}
+	return true
+}
+
+func (rc *recheck) isUntagged(digest string) (bool, error) {
+	tags, err := rc.fsa.ListTags(rc.repo)
+	if err != nil {
+		return false, err
+	}
+	if rc.untagged == nil || !sameTags(tags, rc.tags) {
+		digests, err := ListUntagged(rc.fsa, rc.repo)
+		if err != nil {
+			return false, err
+		}
+		rc.tags = tags
+		rc.untagged = make(map[string]struct{}, len(digests))
+		for _, d := range digests {
+			rc.untagged[d] = struct{}{}
+		}
+	}
+	_, ok := rc.untagged[digest]
+	return ok, nil
+}
+
+func BuildInventory(fsa *fs_analyzer.Analyzer, repo string) (*Inventory, error) {
+	digests, err := ListUntagged(fsa, repo)
+	if err != nil {
+		return nil, err
+	}
+	inventory := &Inventory{Name: repo, Manifests: make([]manifest.Summary, 0, len(digests))}
+	for _, digest := range digests {
+		summary, err := manifest.SummarizeStored(repo, "", digest, fsa.ReadBlob)
+		if err != nil {
+			log.Printf("[ERROR at untagged.BuildInventory]: %s@%s: %v", repo, digest, err)
+			summary = manifest.Summary{Name: repo, ContentDigest: digest}
+		}
+		inventory.Manifests = append(inventory.Manifests, summary)
+		inventory.TotalSize += summary.Size
+	}
+	sort.Slice(inventory.Manifests, func(i, j int) bool {
+		return inventory.Manifests[i].Created < inventory.Manifests[j].Created
+	})
+	return inventory, nil
+}
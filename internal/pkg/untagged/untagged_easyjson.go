@@ -0,0 +1,447 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package untagged
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	manifest "registry-cleaner-agent/internal/pkg/manifest"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged(in *jlexer.Lexer, out *InventoryList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "repositories":
+			if in.IsNull() {
+				in.Skip()
+				out.Repositories = nil
+			} else {
+				in.Delim('[')
+				if out.Repositories == nil {
+					if !in.IsDelim(']') {
+						out.Repositories = make([]Inventory, 0, 1)
+					} else {
+						out.Repositories = []Inventory{}
+					}
+				} else {
+					out.Repositories = (out.Repositories)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Inventory
+					(v1).UnmarshalEasyJSON(in)
+					out.Repositories = append(out.Repositories, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged(out *jwriter.Writer, in InventoryList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"repositories\":"
+		out.RawString(prefix[1:])
+		if in.Repositories == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Repositories {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InventoryList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InventoryList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InventoryList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InventoryList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged(l, v)
+}
+func easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged1(in *jlexer.Lexer, out *Inventory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "manifests":
+			if in.IsNull() {
+				in.Skip()
+				out.Manifests = nil
+			} else {
+				in.Delim('[')
+				if out.Manifests == nil {
+					if !in.IsDelim(']') {
+						out.Manifests = make([]manifest.Summary, 0, 0)
+					} else {
+						out.Manifests = []manifest.Summary{}
+					}
+				} else {
+					out.Manifests = (out.Manifests)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 manifest.Summary
+					(v4).UnmarshalEasyJSON(in)
+					out.Manifests = append(out.Manifests, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "totalSize":
+			out.TotalSize = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged1(out *jwriter.Writer, in Inventory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"manifests\":"
+		out.RawString(prefix)
+		if in.Manifests == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Manifests {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"totalSize\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.TotalSize))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Inventory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Inventory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Inventory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Inventory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged1(l, v)
+}
+func easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged2(in *jlexer.Lexer, out *DeleteReport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "deleted":
+			if in.IsNull() {
+				in.Skip()
+				out.Deleted = nil
+			} else {
+				in.Delim('[')
+				if out.Deleted == nil {
+					if !in.IsDelim(']') {
+						out.Deleted = make([]string, 0, 4)
+					} else {
+						out.Deleted = []string{}
+					}
+				} else {
+					out.Deleted = (out.Deleted)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Deleted = append(out.Deleted, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "failed":
+			if in.IsNull() {
+				in.Skip()
+				out.Failed = nil
+			} else {
+				in.Delim('[')
+				if out.Failed == nil {
+					if !in.IsDelim(']') {
+						out.Failed = make([]DeleteFailure, 0, 2)
+					} else {
+						out.Failed = []DeleteFailure{}
+					}
+				} else {
+					out.Failed = (out.Failed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v8 DeleteFailure
+					(v8).UnmarshalEasyJSON(in)
+					out.Failed = append(out.Failed, v8)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged2(out *jwriter.Writer, in DeleteReport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix)
+		if in.Deleted == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v9, v10 := range in.Deleted {
+				if v9 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v10))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		if in.Failed == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Failed {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeleteReport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeleteReport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeleteReport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeleteReport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged2(l, v)
+}
+func easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged3(in *jlexer.Lexer, out *DeleteFailure) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digest":
+			out.Digest = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged3(out *jwriter.Writer, in DeleteFailure) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digest\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digest))
+	}
+	{
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DeleteFailure) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DeleteFailure) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson86a370dEncodeRegistryCleanerAgentInternalPkgUntagged3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DeleteFailure) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DeleteFailure) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson86a370dDecodeRegistryCleanerAgentInternalPkgUntagged3(l, v)
+}
@@ -0,0 +1,179 @@
+package untagged
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"registry-cleaner-agent/internal/pkg/agent_errors"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"registry-cleaner-agent/internal/pkg/http_response"
+	"registry-cleaner-agent/internal/pkg/registry_client"
+	"time"
+)
+
+type Handler struct {
+	FSAnalyzer *fs_analyzer.Analyzer
+	Client     *registry_client.Client
+	// MinAge keeps recently pushed untagged manifests out of DeleteUntagged
+	MinAge time.Duration
+}
+
+// DefaultMinAge is used when untagged_min_age is not configured
+const DefaultMinAge = time.Hour
+
+func InitUntaggedHandler(fsa *fs_analyzer.Analyzer, client *registry_client.Client) (*Handler, error) {
+	if fsa == nil || client == nil {
+		return nil, agent_errors.NilPointerReference
+	}
+	return &Handler{
+		FSAnalyzer: fsa,
+		Client:     client,
+		MinAge:     DefaultMinAge,
+	}, nil
+}
+
+// Delete removes the given untagged manifests of the repository; tagged digests are refused
+func (uh *Handler) Delete(repo string, digests []string) (*DeleteReport, error) {
+	untagged, err := ListUntagged(uh.FSAnalyzer, repo)
+	if err != nil {
+		return nil, err
+	}
+	isUntagged := make(map[string]struct{}, len(untagged))
+	for _, digest := range untagged {
+		isUntagged[digest] = struct{}{}
+	}
+	report := &DeleteReport{Name: repo, Deleted: []string{}, Failed: []DeleteFailure{}}
+	for _, digest := range digests {
+		if _, ok := isUntagged[digest]; !ok {
+			report.Failed = append(report.Failed, DeleteFailure{Digest: digest, Error: "manifest is tagged or unknown"})
+			continue
+		}
+		err = uh.Client.DeleteManifest(repo, digest)
+		if err != nil {
+			log.Printf("[ERROR at untagged.Handler.Delete]: %s@%s: %v", repo, digest, err)
+			report.Failed = append(report.Failed, DeleteFailure{Digest: digest, Error: err.Error()})
+			continue
+		}
+		report.Deleted = append(report.Deleted, digest)
+	}
+	return report, nil
+}
+
+// DeleteUntagged deletes untagged manifests older than MinAge in every repository, referrers of kept subjects
+// are left in place. Reachability is checked again before each deletion and the first failure stops the run
+func (uh *Handler) DeleteUntagged() (int, error) {
+	repositories, err := uh.FSAnalyzer.ListRepositories()
+	if err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().Add(-uh.MinAge)
+	deleted := 0
+	for _, repo := range repositories {
+		digests, err := ListCollectable(uh.FSAnalyzer, repo, cutoff)
+		if err != nil {
+			return deleted, err
+		}
+		rc := &recheck{fsa: uh.FSAnalyzer, repo: repo}
+		for _, digest := range digests {
+			untagged, err := rc.isUntagged(digest)
+			if err != nil {
+				return deleted, err
+			}
+			if !untagged {
+				continue
+			}
+			if err = uh.Client.DeleteManifest(repo, digest); err != nil {
+				return deleted, fmt.Errorf("delete %s@%s: %w", repo, digest, err)
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// UntaggedGarbage lists blobs DeleteUntagged would turn into garbage
+func (uh *Handler) UntaggedGarbage() ([]string, error) {
+	return Garbage(uh.FSAnalyzer, time.Now().Add(-uh.MinAge))
+}
+
+func (uh *Handler) UntaggedListHandler(w http.ResponseWriter, _ *http.Request) {
+	repositories, err := uh.FSAnalyzer.ListRepositories()
+	if err != nil {
+		log.Printf("[ERROR at untagged.Handler.UntaggedListHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	list := InventoryList{Repositories: []Inventory{}}
+	for _, repo := range repositories {
+		inventory, err := BuildInventory(uh.FSAnalyzer, repo)
+		if err != nil {
+			log.Printf("[ERROR at untagged.Handler.UntaggedListHandler]: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(inventory.Manifests) != 0 {
+			list.Repositories = append(list.Repositories, *inventory)
+		}
+	}
+	http_response.WriteJson(w, &list)
+}
+
+// requireRepository replies with NAME_UNKNOWN and returns false when the repository does not exist
+func (uh *Handler) requireRepository(w http.ResponseWriter, repo string) bool {
+	exists, err := uh.FSAnalyzer.RepositoryExists(repo)
+	if err != nil {
+		log.Printf("[ERROR at untagged.Handler.requireRepository]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	if !exists {
+		agent_errors.WriteRegistryError(w, http.StatusNotFound, agent_errors.CodeNameUnknown,
+			"repository name not known to registry", map[string]string{"name": repo})
+		return false
+	}
+	return true
+}
+
+func (uh *Handler) UntaggedGetHandler(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+	if !uh.requireRepository(w, repo) {
+		return
+	}
+	inventory, err := BuildInventory(uh.FSAnalyzer, repo)
+	if err != nil {
+		log.Printf("[ERROR at untagged.Handler.UntaggedGetHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http_response.WriteJson(w, inventory)
+}
+
+// UntaggedDeleteHandler deletes manifests listed in "digest" query parameters, or every one with "all=true"
+func (uh *Handler) UntaggedDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	repo := mux.Vars(r)["repo"]
+	if !uh.requireRepository(w, repo) {
+		return
+	}
+	query := r.URL.Query()
+	digests := query["digest"]
+	if query.Get("all") == "true" {
+		var err error
+		digests, err = ListUntagged(uh.FSAnalyzer, repo)
+		if err != nil {
+			log.Printf("[ERROR at untagged.Handler.UntaggedDeleteHandler]: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else if len(digests) == 0 {
+		http.Error(w, "digest query parameter or all=true is required", http.StatusBadRequest)
+		return
+	}
+	report, err := uh.Delete(repo, digests)
+	if err != nil {
+		log.Printf("[ERROR at untagged.Handler.UntaggedDeleteHandler]: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http_response.WriteJson(w, report)
+}
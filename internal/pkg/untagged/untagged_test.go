@@ -0,0 +1,241 @@
+package untagged
+
+import (
+	"fmt"
+	"github.com/opencontainers/go-digest"
+	"io/ioutil"
+	"os"
+	"path"
+	"registry-cleaner-agent/internal/pkg/fs_analyzer"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testStorage writes blobs and repository links the way the registry lays them out
+type testStorage struct {
+	t    *testing.T
+	root string
+	// digests maps names given to blobs by the test to their digests
+	digests map[string]string
+}
+
+func newTestStorage(t *testing.T) *testStorage {
+	return &testStorage{t: t, root: t.TempDir(), digests: make(map[string]string)}
+}
+
+func (ts *testStorage) write(file string, data []byte) {
+	ts.t.Helper()
+	if err := os.MkdirAll(path.Dir(file), 0700); err != nil {
+		ts.t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		ts.t.Fatal(err)
+	}
+}
+
+// blob stores the content under the name and returns its descriptor JSON
+func (ts *testStorage) blob(name, mediaType, content string) string {
+	d := digest.FromString(content)
+	ts.digests[name] = d.String()
+	ts.write(path.Join(ts.root, fs_analyzer.BlobsPath, "sha256", d.Encoded()[:2], d.Encoded(), "data"), []byte(content))
+	return fmt.Sprintf(`{"mediaType": %q, "digest": %q, "size": %d}`, mediaType, d, len(content))
+}
+
+// image stores an OCI image manifest with a config and the given layers, subject makes it a referrer
+func (ts *testStorage) image(name, subject string, layers ...string) string {
+	config := ts.blob(name+"/config", "application/vnd.oci.image.config.v1+json", `{"architecture": "`+name+`"}`)
+	descriptors := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		descriptors = append(descriptors, ts.blob(layer, "application/vnd.oci.image.layer.v1.tar", layer))
+	}
+	content := fmt.Sprintf(`{"schemaVersion": 2, "mediaType": "application/vnd.oci.image.manifest.v1+json", `+
+		`"config": %s, "layers": [%s]`, config, strings.Join(descriptors, ", "))
+	if subject != "" {
+		content += fmt.Sprintf(`, "subject": {"mediaType": "application/vnd.oci.image.manifest.v1+json", `+
+			`"digest": %q, "size": 1}`, ts.digests[subject])
+	}
+	return ts.blob(name, "application/vnd.oci.image.manifest.v1+json", content+"}")
+}
+
+// index stores an OCI index of previously stored manifests
+func (ts *testStorage) index(name string, children ...string) {
+	content := fmt.Sprintf(`{"schemaVersion": 2, "mediaType": "application/vnd.oci.image.index.v1+json", `+
+		`"manifests": [%s]}`, strings.Join(children, ", "))
+	ts.blob(name, "application/vnd.oci.image.index.v1+json", content)
+}
+
+func (ts *testStorage) repositoryPath(repo string, elem ...string) string {
+	return path.Join(append([]string{ts.root, fs_analyzer.RepositoriesPath, repo}, elem...)...)
+}
+
+// link adds the named manifests as revisions of the repository, linked at the given time
+func (ts *testStorage) link(repo string, linkedAt time.Time, names ...string) {
+	for _, name := range names {
+		d := digest.Digest(ts.digests[name])
+		revision := ts.repositoryPath(repo, fs_analyzer.ManifestsDir, fs_analyzer.RevisionsDir, "sha256",
+			d.Encoded(), fs_analyzer.LinkFilename)
+		ts.write(revision, []byte(d))
+		if err := os.Chtimes(revision, linkedAt, linkedAt); err != nil {
+			ts.t.Fatal(err)
+		}
+	}
+}
+
+// tag points the tag at the named manifest, a tag starting with "cosign:" is replaced by the cosign
+// signature tag of the manifest named after the prefix
+func (ts *testStorage) tag(repo, tag, name string) {
+	if strings.HasPrefix(tag, "cosign:") {
+		tag = strings.Replace(ts.digests[strings.TrimPrefix(tag, "cosign:")], ":", "-", 1) + ".sig"
+	}
+	current := ts.repositoryPath(repo, fs_analyzer.ManifestsDir, fs_analyzer.TagsDir, tag, fs_analyzer.CurrentTagDir,
+		fs_analyzer.LinkFilename)
+	ts.write(current, []byte(ts.digests[name]))
+}
+
+// names translates digests back to the names given by the test
+func (ts *testStorage) names(digests []string) []string {
+	byDigest := make(map[string]string, len(ts.digests))
+	for name, d := range ts.digests {
+		byDigest[d] = name
+	}
+	res := make([]string, 0, len(digests))
+	for _, d := range digests {
+		res = append(res, byDigest[d])
+	}
+	sort.Strings(res)
+	return res
+}
+
+// newTestRepository stores team/api with a two-platform index, a stray image, a signature and an SBOM of
+// the amd64 image, a signature of the SBOM, a signature of the stray image and a cosign signature of the index
+func newTestRepository(t *testing.T) *testStorage {
+	ts := newTestStorage(t)
+	amd64 := ts.image("amd64", "", "layer-a", "layer-shared")
+	arm64 := ts.image("arm64", "", "layer-b", "layer-shared")
+	ts.index("index", amd64, arm64)
+	ts.image("stray", "", "layer-c", "layer-shared")
+	ts.image("signature", "amd64")
+	ts.image("sbom", "amd64", "layer-sbom")
+	ts.image("sbom-signature", "sbom")
+	ts.image("stray-signature", "stray")
+	ts.image("cosign", "")
+	ts.link("team/api", time.Now().Add(-2*time.Hour),
+		"index", "amd64", "arm64", "stray", "signature", "sbom", "sbom-signature", "stray-signature", "cosign")
+	return ts
+}
+
+func TestListUntagged(t *testing.T) {
+	all := []string{"amd64", "arm64", "cosign", "index", "sbom", "sbom-signature", "signature", "stray",
+		"stray-signature"}
+	tests := []struct {
+		name string
+		tags map[string]string
+		want []string
+	}{
+		{"nothing tagged", nil, all},
+		{"index keeps children and their referrers", map[string]string{"latest": "index"},
+			[]string{"cosign", "stray", "stray-signature"}},
+		{"image keeps its referrers", map[string]string{"amd64": "amd64"},
+			[]string{"arm64", "cosign", "index", "stray", "stray-signature"}},
+		{"cosign tag follows its subject", map[string]string{"latest": "index", "cosign:index": "cosign"},
+			[]string{"stray", "stray-signature"}},
+		{"cosign tag without subject", map[string]string{"cosign:index": "cosign"}, all},
+		{"referrer kept by the subject only", map[string]string{"sig": "stray-signature"},
+			[]string{"amd64", "arm64", "cosign", "index", "sbom", "sbom-signature", "signature", "stray"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestRepository(t)
+			for tag, name := range tt.tags {
+				ts.tag("team/api", tag, name)
+			}
+			untagged, err := ListUntagged(fs_analyzer.NewFSAnalyzer(ts.root), "team/api")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ts.names(untagged); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListCollectable(t *testing.T) {
+	ts := newTestRepository(t)
+	ts.tag("team/api", "latest", "index")
+	// A list being pushed: children are linked before the list gets tagged
+	pushed := ts.image("pushed", "", "layer-d")
+	ts.index("pushed-index", pushed)
+	ts.link("team/api", time.Now(), "pushed", "pushed-index")
+	collectable, err := ListCollectable(fs_analyzer.NewFSAnalyzer(ts.root), "team/api", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"cosign", "stray", "stray-signature"}
+	if got := ts.names(collectable); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGarbage(t *testing.T) {
+	tests := []struct {
+		name string
+		// other links manifests of team/api to team/web as well
+		other []string
+		want  []string
+	}{
+		{"blobs of untagged manifests", nil,
+			[]string{"cosign", "cosign/config", "layer-c", "stray", "stray-signature", "stray-signature/config",
+				"stray/config"}},
+		{"blobs kept by another repository", []string{"stray"},
+			[]string{"cosign", "cosign/config", "stray-signature", "stray-signature/config"}},
+		{"blobs of untagged manifests of another repository", []string{"amd64"},
+			[]string{"cosign", "cosign/config", "layer-c", "stray", "stray-signature", "stray-signature/config",
+				"stray/config"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestRepository(t)
+			ts.tag("team/api", "latest", "index")
+			if tt.other != nil {
+				ts.link("team/web", time.Now(), tt.other...)
+			}
+			garbage, err := Garbage(fs_analyzer.NewFSAnalyzer(ts.root), time.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ts.names(garbage); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecheck(t *testing.T) {
+	ts := newTestRepository(t)
+	ts.tag("team/api", "latest", "index")
+	rc := &recheck{fsa: fs_analyzer.NewFSAnalyzer(ts.root), repo: "team/api"}
+	for _, step := range []struct {
+		tag  string
+		name string
+		want bool
+	}{
+		{"", "stray", true},
+		{"", "amd64", false},
+		{"stray", "stray", false},
+		{"stray", "stray-signature", false},
+	} {
+		if step.tag != "" {
+			ts.tag("team/api", step.tag, step.name)
+		}
+		untagged, err := rc.isUntagged(ts.digests[step.name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if untagged != step.want {
+			t.Errorf("%s after tagging %q: untagged %v, want %v", step.name, step.tag, untagged, step.want)
+		}
+	}
+}